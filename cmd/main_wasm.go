@@ -6,10 +6,12 @@ package main
 import (
 	"syscall/js"
 
+	"flagged-it/internal/utils"
+
 	"fyne.io/fyne/v2"
 )
 
-// setupVisibilityHandler pauses rendering when tab is hidden to prevent freezing
+// setupVisibilityHandler pauses rendering and game timers when tab is hidden to prevent freezing
 func setupVisibilityHandler(window fyne.Window) {
 	doc := js.Global().Get("document")
 
@@ -17,6 +19,9 @@ func setupVisibilityHandler(window fyne.Window) {
 	visibilityChangeCallback := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
 		hidden := doc.Get("hidden").Bool()
 
+		// Countdowns in timed modes stop counting while the tab is hidden
+		utils.SetAppHidden(hidden)
+
 		if hidden {
 			// Tab is hidden - canvas will automatically reduce rendering
 			// This helps prevent the tab from freezing
@@ -1,12 +1,15 @@
 package app
 
 import (
+	"flagged-it/internal/games/currency"
 	"flagged-it/internal/games/facts"
 	"flagged-it/internal/games/flag"
 	"flagged-it/internal/games/guessing"
 	"flagged-it/internal/games/hangman"
 	"flagged-it/internal/games/higher_lower"
+	"flagged-it/internal/games/languages"
 	"flagged-it/internal/games/list"
+	"flagged-it/internal/games/ranking"
 	"flagged-it/internal/games/shape"
 	"flagged-it/internal/ui/screens"
 
@@ -49,7 +52,7 @@ func (a *App) navigateToGame(gameType string) {
 	case "hangman":
 		game := hangman.NewGame(a.backToDashboard)
 		a.window.SetContent(game.GetContent())
-		a.window.Canvas().SetOnTypedKey(game.TypedKey)
+		a.window.Canvas().SetOnTypedRune(game.TypedRune)
 	case "facts":
 		game := facts.NewGame(a.backToDashboard)
 		a.window.SetContent(game.GetContent())
@@ -61,7 +64,16 @@ func (a *App) navigateToGame(gameType string) {
 		a.window.SetContent(game.GetContent())
 	case "flag_europe":
 		game := flag.NewGame(a.backToDashboard)
-		game.SetRegion("Europe")
+		game.PreselectRegion("Europe")
+		a.window.SetContent(game.GetContent())
+	case "currency":
+		game := currency.NewGame(a.backToDashboard)
+		a.window.SetContent(game.GetContent())
+	case "languages":
+		game := languages.NewGame(a.backToDashboard)
+		a.window.SetContent(game.GetContent())
+	case "ranking":
+		game := ranking.NewGame(a.backToDashboard)
 		a.window.SetContent(game.GetContent())
 	case "guessing":
 		game := guessing.NewGame(a.backToDashboard)
@@ -4,7 +4,10 @@ import (
 	"embed"
 	"encoding/json"
 	"flagged-it/internal/data/models"
+	"flagged-it/internal/utils"
 	"sync"
+
+	"golang.org/x/text/language"
 )
 
 //go:embed sources/countries_main.json
@@ -13,14 +16,33 @@ var countriesData []byte
 //go:embed sources/countries_facts.json
 var factsData []byte
 
+// Translated facts, one file per language named by its base code
+//
+//go:embed sources/facts/*.json
+var localizedFactsFS embed.FS
+
 //go:embed sources/geo/*.json
 var geoFS embed.FS
 
+//go:generate go run gen_flag_similarity.go
+//go:embed sources/flag_similarity.json
+var flagSimilarityData []byte
+
+//go:generate go run gen_borders.go
+//go:embed sources/borders.json
+var bordersData []byte
+
 var (
 	cachedCountries    []models.Country
 	cachedCountryFacts map[string]models.CountryFacts
+	localizedFacts     = make(map[string]map[string]models.CountryFacts)
+	localizedFactsMu   sync.Mutex
+	cachedSimilarity   map[string][]string
+	cachedBorders      map[string][]string
 	countriesOnce      sync.Once
 	factsOnce          sync.Once
+	similarityOnce     sync.Once
+	bordersOnce        sync.Once
 )
 
 func LoadCountries() []models.Country {
@@ -30,11 +52,58 @@ func LoadCountries() []models.Country {
 	return cachedCountries
 }
 
+// LoadCountryFacts returns the facts for the current locale. Countries not
+// yet translated into it keep their English facts.
 func LoadCountryFacts() map[string]models.CountryFacts {
 	factsOnce.Do(func() {
 		json.Unmarshal(factsData, &cachedCountryFacts)
 	})
-	return cachedCountryFacts
+
+	base, _ := language.Make(utils.GetCurrentLocale()).Base()
+	locale := base.String()
+	if locale == "en" {
+		return cachedCountryFacts
+	}
+
+	localizedFactsMu.Lock()
+	defer localizedFactsMu.Unlock()
+	if facts, ok := localizedFacts[locale]; ok {
+		return facts
+	}
+
+	facts := make(map[string]models.CountryFacts, len(cachedCountryFacts))
+	for code, countryFacts := range cachedCountryFacts {
+		facts[code] = countryFacts
+	}
+	if file, err := localizedFactsFS.ReadFile("sources/facts/" + locale + ".json"); err == nil {
+		var translated map[string]models.CountryFacts
+		json.Unmarshal(file, &translated)
+		for code, countryFacts := range translated {
+			if len(countryFacts.Facts) > 0 {
+				facts[code] = countryFacts
+			}
+		}
+	}
+	localizedFacts[locale] = facts
+	return facts
+}
+
+// LoadFlagSimilarity returns, for each CCA2 code, the codes of the most
+// similar looking flags, most similar first
+func LoadFlagSimilarity() map[string][]string {
+	similarityOnce.Do(func() {
+		json.Unmarshal(flagSimilarityData, &cachedSimilarity)
+	})
+	return cachedSimilarity
+}
+
+// LoadBorders returns, for each CCA3 code, the codes of the countries whose
+// geo outlines touch it
+func LoadBorders() map[string][]string {
+	bordersOnce.Do(func() {
+		json.Unmarshal(bordersData, &cachedBorders)
+	})
+	return cachedBorders
 }
 
 func LoadGeoData(cca3 string) (models.GeoJSON, error) {
@@ -0,0 +1,126 @@
+//go:build ignore
+
+// This program finds which countries share a border in the embedded geo
+// polygons and writes them to sources/borders.json, keyed by CCA3 code.
+// Run it with `go generate ./internal/data` after changing the geo data.
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// tolerance is how close, in degrees, two countries' outlines must come to
+// count as neighbours. Shared borders are digitized separately per country,
+// so their points never match exactly.
+const tolerance = 0.05
+
+type geoJSON struct {
+	Features []struct {
+		Geometry struct {
+			Type        string          `json:"type"`
+			Coordinates json.RawMessage `json:"coordinates"`
+		} `json:"geometry"`
+	} `json:"features"`
+}
+
+type cell struct{ x, y int }
+
+type point struct {
+	code string
+	x, y float64
+}
+
+func main() {
+	files, err := filepath.Glob("sources/geo/*.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Bucket every outline point into a grid so only nearby points are compared
+	grid := make(map[cell][]point)
+	for _, file := range files {
+		code := strings.TrimSuffix(filepath.Base(file), ".json")
+		polygons, err := readPolygons(file)
+		if err != nil {
+			log.Printf("skipping %s: %v", code, err)
+			continue
+		}
+		for _, polygon := range polygons {
+			for _, ring := range polygon {
+				for _, p := range ring {
+					if len(p) < 2 {
+						continue
+					}
+					c := cell{int(math.Floor(p[0] / tolerance)), int(math.Floor(p[1] / tolerance))}
+					grid[c] = append(grid[c], point{code, p[0], p[1]})
+				}
+			}
+		}
+	}
+
+	neighbours := make(map[string]map[string]bool)
+	for c, points := range grid {
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				for _, other := range grid[cell{c.x + dx, c.y + dy}] {
+					for _, p := range points {
+						if p.code == other.code || math.Hypot(p.x-other.x, p.y-other.y) > tolerance {
+							continue
+						}
+						if neighbours[p.code] == nil {
+							neighbours[p.code] = make(map[string]bool)
+						}
+						neighbours[p.code][other.code] = true
+					}
+				}
+			}
+		}
+	}
+
+	table := make(map[string][]string)
+	for code, set := range neighbours {
+		for other := range set {
+			table[code] = append(table[code], other)
+		}
+		sort.Strings(table[code])
+	}
+
+	out, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("sources/borders.json", append(out, '\n'), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readPolygons returns the outline of the first feature as a MultiPolygon
+func readPolygons(path string) ([][][][]float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var geo geoJSON
+	if err := json.Unmarshal(data, &geo); err != nil {
+		return nil, err
+	}
+	if len(geo.Features) == 0 {
+		return nil, nil
+	}
+
+	geometry := geo.Features[0].Geometry
+	if geometry.Type == "Polygon" {
+		var polygon [][][]float64
+		err := json.Unmarshal(geometry.Coordinates, &polygon)
+		return [][][][]float64{polygon}, err
+	}
+	var polygons [][][][]float64
+	err = json.Unmarshal(geometry.Coordinates, &polygons)
+	return polygons, err
+}
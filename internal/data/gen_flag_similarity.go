@@ -0,0 +1,198 @@
+//go:build ignore
+
+// This program rasterizes the twemoji flags and writes, for every country,
+// the flags that look most alike to sources/flag_similarity.json.
+// Run it with `go generate ./internal/data` after adding or changing flags.
+package main
+
+import (
+	"encoding/json"
+	"image"
+	"image/color"
+	"log"
+	"math"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/fyne-io/oksvg"
+	"github.com/srwiley/rasterx"
+)
+
+const (
+	// Twemoji flags fill y 5..31 of a 36x36 view box
+	rasterSize = 72
+	flagTop    = 10
+	flagBottom = 62
+	// lookalikes is how many neighbours are stored per flag
+	lookalikes = 8
+	// paletteLevels quantizes each channel for the colour histogram
+	paletteLevels = 4
+	gridColumns   = 6
+	gridRows      = 4
+)
+
+type country struct {
+	CCA2 string `json:"cca2"`
+}
+
+type signature struct {
+	palette []float64 // normalized colour histogram
+	grid    []color.RGBA
+	hash    uint64 // difference hash of the grayscale image
+}
+
+func main() {
+	data, err := os.ReadFile("sources/countries_main.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+	var countries []country
+	if err := json.Unmarshal(data, &countries); err != nil {
+		log.Fatal(err)
+	}
+
+	signatures := make(map[string]signature)
+	for _, c := range countries {
+		img, err := rasterize(filepath.Join("..", "..", "assets", "twemoji_flags_cca2", c.CCA2+".svg"))
+		if err != nil {
+			log.Printf("skipping %s: %v", c.CCA2, err)
+			continue
+		}
+		signatures[c.CCA2] = sign(img)
+	}
+
+	table := make(map[string][]string)
+	for code, sig := range signatures {
+		type neighbour struct {
+			code     string
+			distance float64
+		}
+		var neighbours []neighbour
+		for other, otherSig := range signatures {
+			if other != code {
+				neighbours = append(neighbours, neighbour{other, distance(sig, otherSig)})
+			}
+		}
+		sort.Slice(neighbours, func(i, j int) bool {
+			if neighbours[i].distance == neighbours[j].distance {
+				return neighbours[i].code < neighbours[j].code
+			}
+			return neighbours[i].distance < neighbours[j].distance
+		})
+		for _, n := range neighbours[:lookalikes] {
+			table[code] = append(table[code], n.code)
+		}
+	}
+
+	out, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("sources/flag_similarity.json", append(out, '\n'), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func rasterize(path string) (*image.RGBA, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	icon, err := oksvg.ReadIconStream(f)
+	if err != nil {
+		return nil, err
+	}
+	icon.SetTarget(0, 0, rasterSize, rasterSize)
+	img := image.NewRGBA(image.Rect(0, 0, rasterSize, rasterSize))
+	scanner := rasterx.NewScannerGV(rasterSize, rasterSize, img, img.Bounds())
+	icon.Draw(rasterx.NewDasher(rasterSize, rasterSize, scanner), 1)
+	return img.SubImage(image.Rect(0, flagTop, rasterSize, flagBottom)).(*image.RGBA), nil
+}
+
+func sign(img *image.RGBA) signature {
+	bounds := img.Bounds()
+	sig := signature{
+		palette: make([]float64, paletteLevels*paletteLevels*paletteLevels),
+		grid:    make([]color.RGBA, gridColumns*gridRows),
+	}
+
+	type sum struct{ r, g, b, n int }
+	cells := make([]sum, gridColumns*gridRows)
+	opaque := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := img.RGBAAt(x, y)
+			// Skip the transparent rounded corners
+			if c.A < 128 {
+				continue
+			}
+			opaque++
+			bin := quantize(c.R)*paletteLevels*paletteLevels + quantize(c.G)*paletteLevels + quantize(c.B)
+			sig.palette[bin]++
+
+			cell := (y-bounds.Min.Y)*gridRows/bounds.Dy()*gridColumns + (x-bounds.Min.X)*gridColumns/bounds.Dx()
+			cells[cell].r += int(c.R)
+			cells[cell].g += int(c.G)
+			cells[cell].b += int(c.B)
+			cells[cell].n++
+		}
+	}
+	for i := range sig.palette {
+		sig.palette[i] /= float64(opaque)
+	}
+	for i, cell := range cells {
+		if cell.n > 0 {
+			sig.grid[i] = color.RGBA{uint8(cell.r / cell.n), uint8(cell.g / cell.n), uint8(cell.b / cell.n), 255}
+		}
+	}
+
+	// Difference hash: compare neighbouring cells of a 9x8 grayscale thumbnail
+	var gray [8][9]float64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 9; x++ {
+			px := img.RGBAAt(bounds.Min.X+x*bounds.Dx()/9+bounds.Dx()/18, bounds.Min.Y+y*bounds.Dy()/8+bounds.Dy()/16)
+			gray[y][x] = 0.299*float64(px.R) + 0.587*float64(px.G) + 0.114*float64(px.B)
+		}
+	}
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			sig.hash <<= 1
+			if gray[y][x] > gray[y][x+1] {
+				sig.hash |= 1
+			}
+		}
+	}
+	return sig
+}
+
+func quantize(v uint8) int {
+	return int(v) * paletteLevels / 256
+}
+
+// distance mixes palette, layout and hash differences into a 0..1 score
+func distance(a, b signature) float64 {
+	// Histogram intersection
+	overlap := 0.0
+	for i := range a.palette {
+		overlap += math.Min(a.palette[i], b.palette[i])
+	}
+	palette := 1 - overlap
+
+	// Mean colour difference of the layout grid
+	grid := 0.0
+	for i := range a.grid {
+		dr := float64(a.grid[i].R) - float64(b.grid[i].R)
+		dg := float64(a.grid[i].G) - float64(b.grid[i].G)
+		db := float64(a.grid[i].B) - float64(b.grid[i].B)
+		grid += math.Sqrt(dr*dr+dg*dg+db*db) / (255 * math.Sqrt(3))
+	}
+	grid /= float64(len(a.grid))
+
+	hash := float64(bits.OnesCount64(a.hash^b.hash)) / 64
+
+	return 0.4*palette + 0.4*grid + 0.2*hash
+}
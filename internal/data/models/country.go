@@ -5,20 +5,46 @@ type CountryName struct {
 	Official string `json:"official"`
 }
 
+type CapitalInfo struct {
+	Latlng []float64 `json:"latlng"`
+}
+
+type Currency struct {
+	Name   string `json:"name"`
+	Symbol string `json:"symbol"`
+}
+
 type Country struct {
-	Name       CountryName       `json:"name"`
-	CCA2       string            `json:"cca2"`
-	CCA3       string            `json:"cca3"`
-	Capital    []string          `json:"capital"`
-	Region     string            `json:"region"`
-	Subregion  string            `json:"subregion"`
-	Languages  map[string]string `json:"languages"`
-	Latlng     []float64         `json:"latlng"`
-	Population int               `json:"population"`
-	Area       float64           `json:"area"`
+	Name         CountryName         `json:"name"`
+	CCA2         string              `json:"cca2"`
+	CCA3         string              `json:"cca3"`
+	Capital      []string            `json:"capital"`
+	CapitalInfo  CapitalInfo         `json:"capitalInfo"`
+	Region       string              `json:"region"`
+	Subregion    string              `json:"subregion"`
+	Languages    map[string]string   `json:"languages"`
+	Currencies   map[string]Currency `json:"currencies"`
+	Latlng       []float64           `json:"latlng"`
+	Population   int                 `json:"population"`
+	Area         float64             `json:"area"`
+	Independence int                 `json:"independence"`
+	Temperature  *float64            `json:"temperature"`
 }
 
 type CountryFacts struct {
-	Name  string   `json:"name"`
-	Facts []string `json:"facts"`
+	Name  string `json:"name"`
+	Facts []Fact `json:"facts"`
+	// Redact lists further words that give the country away, such as its
+	// capital in the facts' language
+	Redact []string `json:"redact,omitempty"`
+}
+
+// Fact is one clue about a country, in markdown
+type Fact struct {
+	Text string `json:"text"`
+	// Difficulty runs from 1, nearly gives the country away, to 3, only
+	// experts will know
+	Difficulty int `json:"difficulty"`
+	// Source optionally cites where the fact can be checked, usually a URL
+	Source string `json:"source,omitempty"`
 }
@@ -20,3 +20,58 @@ type Geometry struct {
 	Type        string      `json:"type"`
 	Coordinates interface{} `json:"coordinates"`
 }
+
+// Polygons returns a Polygon or MultiPolygon's coordinates as
+// [polygon][ring][point]{lon, lat}; other geometry types have none
+func (g Geometry) Polygons() [][][][]float64 {
+	var coords [][][][]float64
+
+	switch g.Type {
+	case "Polygon":
+		if polygonCoords := parsePolygon(g.Coordinates); len(polygonCoords) > 0 {
+			coords = [][][][]float64{polygonCoords}
+		}
+	case "MultiPolygon":
+		if multiCoords, ok := g.Coordinates.([]interface{}); ok {
+			for _, poly := range multiCoords {
+				if polygonCoords := parsePolygon(poly); len(polygonCoords) > 0 {
+					coords = append(coords, polygonCoords)
+				}
+			}
+		}
+	}
+
+	return coords
+}
+
+func parsePolygon(coordsInterface interface{}) [][][]float64 {
+	var polygonCoords [][][]float64
+
+	if coordsArray, ok := coordsInterface.([]interface{}); ok {
+		for _, ringInterface := range coordsArray {
+			if ring := parseRing(ringInterface); len(ring) > 0 {
+				polygonCoords = append(polygonCoords, ring)
+			}
+		}
+	}
+
+	return polygonCoords
+}
+
+func parseRing(ringInterface interface{}) [][]float64 {
+	var ring [][]float64
+
+	if ringArray, ok := ringInterface.([]interface{}); ok {
+		for _, pointInterface := range ringArray {
+			if pointArray, ok := pointInterface.([]interface{}); ok && len(pointArray) >= 2 {
+				if lon, ok1 := pointArray[0].(float64); ok1 {
+					if lat, ok2 := pointArray[1].(float64); ok2 {
+						ring = append(ring, []float64{lon, lat})
+					}
+				}
+			}
+		}
+	}
+
+	return ring
+}
@@ -0,0 +1,338 @@
+package currency
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"flagged-it/internal/data"
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/games/rounds"
+	"flagged-it/internal/ui/components"
+	"flagged-it/internal/utils"
+	"flagged-it/pkg/assets"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+const totalRounds = 10
+
+type questionType int
+
+const (
+	// countryToCurrency asks which currency a country uses
+	countryToCurrency questionType = iota
+	// currencyToCountry asks which of the listed countries uses a currency
+	currencyToCountry
+)
+
+// option is a single answer button; code is a currency code or a CCA2
+type option struct {
+	label string
+	code  string
+}
+
+type Game struct {
+	content         *fyne.Container
+	backFunc        func()
+	countries       []models.Country
+	currencies      map[string]models.Currency
+	currencyUsers   map[string]map[string]bool // currency code -> set of CCA2
+	currentCountry  *models.Country
+	currentCurrency string
+	question        questionType
+	plan            *rounds.Plan
+	options         []option
+	flagImage       *canvas.Image
+	statusLabel     *widget.Label
+	buttons         []*components.ColoredButton
+	buttonGrid      *fyne.Container
+	score           int
+	total           int
+	startTime       time.Time
+	gameProgress    *components.GameProgress
+}
+
+func NewGame(backFunc func()) *Game {
+	g := &Game{
+		backFunc: backFunc,
+	}
+	g.loadCountries()
+	g.setupUI()
+	g.newGame()
+	return g
+}
+
+// loadCountries keeps only countries with a currency and indexes which
+// countries share each currency (EUR, XOF, USD, ...)
+func (g *Game) loadCountries() {
+	g.currencies = make(map[string]models.Currency)
+	g.currencyUsers = make(map[string]map[string]bool)
+
+	for _, country := range data.LoadCountries() {
+		if len(country.Currencies) == 0 {
+			continue
+		}
+		g.countries = append(g.countries, country)
+		for code, cur := range country.Currencies {
+			g.currencies[code] = cur
+			if g.currencyUsers[code] == nil {
+				g.currencyUsers[code] = make(map[string]bool)
+			}
+			g.currencyUsers[code][country.CCA2] = true
+		}
+	}
+}
+
+func (g *Game) setupUI() {
+	topBar := components.NewTopBar(lang.X("game.currency.title", "Guess the Currency"), g.backFunc, g.Reset)
+
+	g.flagImage = canvas.NewImageFromResource(nil)
+	g.flagImage.FillMode = canvas.ImageFillContain
+	if utils.IsMobile() {
+		g.flagImage.SetMinSize(fyne.NewSize(240, 144))
+	} else {
+		g.flagImage.SetMinSize(fyne.NewSize(400, 240))
+	}
+
+	g.statusLabel = widget.NewLabel("")
+	g.statusLabel.Wrapping = fyne.TextWrapWord
+
+	columns := 2
+	if utils.IsMobile() {
+		columns = 1
+	}
+	g.buttonGrid = container.NewGridWithColumns(columns)
+
+	g.gameProgress = components.NewGameProgress(components.GameProgressConfig{
+		ShowRounds:      true,
+		ShowPercentage:  true,
+		ShowProgressBar: true,
+	})
+
+	headerSection := container.NewVBox(
+		topBar.GetContainer(),
+		g.gameProgress.GetContainer(),
+		g.statusLabel,
+	)
+
+	g.content = container.NewBorder(
+		headerSection,
+		container.NewVBox(g.buttonGrid),
+		nil, nil,
+		container.NewCenter(g.flagImage),
+	)
+}
+
+func (g *Game) newGame() {
+	if len(g.countries) == 0 {
+		g.statusLabel.SetText(lang.X("error.loading_countries", "Error loading countries data"))
+		return
+	}
+	if g.total == 0 {
+		g.startTime = time.Now()
+		plan, err := rounds.New(g.countries, rounds.Config{Rounds: totalRounds})
+		if err != nil {
+			g.statusLabel.SetText(rounds.ErrorText(err))
+			return
+		}
+		g.plan = plan
+	}
+
+	country := &g.plan.Rounds[g.total].Answer
+	g.currentCountry = country
+	g.question = questionType(rand.Intn(2))
+
+	switch g.question {
+	case countryToCurrency:
+		g.buildCurrencyOptions()
+		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.currency.question_currency", "Which currency is used in %s?"), country.Name.Common))
+		g.displayFlag()
+	case currencyToCountry:
+		g.buildCountryOptions()
+		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.currency.question_country", "Which of these countries uses the %s?"), g.currencyLabel(g.currentCurrency)))
+		g.flagImage.Resource = nil
+		g.flagImage.Refresh()
+	}
+
+	rand.Shuffle(len(g.options), func(i, j int) {
+		g.options[i], g.options[j] = g.options[j], g.options[i]
+	})
+	g.createButtons()
+}
+
+// buildCurrencyOptions picks one of the country's currencies as the answer and
+// three currencies the country does not use as distractors
+func (g *Game) buildCurrencyOptions() {
+	own := sortedKeys(g.currentCountry.Currencies)
+	g.currentCurrency = own[rand.Intn(len(own))]
+	g.options = []option{{label: g.currencyLabel(g.currentCurrency), code: g.currentCurrency}}
+
+	var candidates []string
+	for code := range g.currencies {
+		if _, used := g.currentCountry.Currencies[code]; !used {
+			candidates = append(candidates, code)
+		}
+	}
+	sort.Strings(candidates)
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	for _, code := range candidates {
+		if len(g.options) == 4 {
+			break
+		}
+		g.options = append(g.options, option{label: g.currencyLabel(code), code: code})
+	}
+}
+
+// buildCountryOptions picks a currency of the current country and three
+// countries that do not use it as distractors
+func (g *Game) buildCountryOptions() {
+	own := sortedKeys(g.currentCountry.Currencies)
+	g.currentCurrency = own[rand.Intn(len(own))]
+	g.options = []option{{label: g.currentCountry.Name.Common, code: g.currentCountry.CCA2}}
+
+	for _, i := range rand.Perm(len(g.countries)) {
+		if len(g.options) == 4 {
+			break
+		}
+		candidate := g.countries[i]
+		if g.currencyUsers[g.currentCurrency][candidate.CCA2] {
+			continue
+		}
+		g.options = append(g.options, option{label: candidate.Name.Common, code: candidate.CCA2})
+	}
+}
+
+func (g *Game) currencyLabel(code string) string {
+	cur := g.currencies[code]
+	if cur.Symbol == "" {
+		return cur.Name
+	}
+	return fmt.Sprintf("%s (%s)", cur.Name, cur.Symbol)
+}
+
+// isValid reports whether an option is an acceptable answer. Shared currencies
+// mean several countries (or several currencies) can be right at once.
+func (g *Game) isValid(opt option) bool {
+	if g.question == countryToCurrency {
+		_, ok := g.currentCountry.Currencies[opt.code]
+		return ok
+	}
+	return g.currencyUsers[g.currentCurrency][opt.code]
+}
+
+func (g *Game) displayFlag() {
+	if flagResource, err := assets.LoadFlagResource(g.currentCountry.CCA2); err == nil {
+		g.flagImage.Resource = flagResource
+	}
+	g.flagImage.Refresh()
+}
+
+func (g *Game) createButtons() {
+	g.buttonGrid.RemoveAll()
+
+	g.buttons = make([]*components.ColoredButton, len(g.options))
+	for i, opt := range g.options {
+		opt := opt
+		btn := components.NewColoredButton(opt.label, func() {
+			g.makeGuess(opt)
+		})
+		g.buttons[i] = btn
+		g.buttonGrid.Add(btn)
+	}
+	g.buttonGrid.Refresh()
+}
+
+func (g *Game) makeGuess(guessed option) {
+	g.total++
+	isCorrect := g.isValid(guessed)
+	if isCorrect {
+		g.score++
+	}
+	g.statusLabel.SetText(g.resultText(guessed, isCorrect))
+
+	g.gameProgress.UpdateProgress(g.total, g.plan.Len(), g.score)
+
+	for i, btn := range g.buttons {
+		if g.isValid(g.options[i]) {
+			btn.SetBgColor(components.CorrectAnswerColor)
+		} else {
+			btn.SetBgColor(components.WrongAnswerColor)
+		}
+		btn.Disable()
+	}
+
+	if g.total >= g.plan.Len() {
+		finalPercent := float64(g.score) / float64(g.plan.Len()) * 100
+
+		utils.SaveScore(utils.ScoreEntry{
+			GameMode: "currency",
+			Score:    g.score,
+			Total:    g.plan.Len(),
+			Percent:  finalPercent,
+			Duration: int(time.Since(g.startTime).Seconds()),
+		})
+
+		time.AfterFunc(1500*time.Millisecond, func() {
+			fyne.Do(func() {
+				g.statusLabel.SetText(lang.L("game.complete", map[string]any{"Score": g.score, "Percent": int(finalPercent)}))
+			})
+		})
+	} else {
+		time.AfterFunc(1500*time.Millisecond, func() {
+			fyne.Do(func() {
+				g.newGame()
+			})
+		})
+	}
+}
+
+func (g *Game) resultText(guessed option, isCorrect bool) string {
+	if g.question == countryToCurrency {
+		var names []string
+		for _, code := range sortedKeys(g.currentCountry.Currencies) {
+			names = append(names, g.currencyLabel(code))
+		}
+		if isCorrect {
+			return fmt.Sprintf(lang.X("game.currency.correct", "Correct! %s uses the %s"), g.currentCountry.Name.Common, strings.Join(names, ", "))
+		}
+		return fmt.Sprintf(lang.X("game.currency.wrong", "Wrong! %s uses the %s"), g.currentCountry.Name.Common, strings.Join(names, ", "))
+	}
+
+	if isCorrect {
+		return fmt.Sprintf(lang.X("game.currency.correct", "Correct! %s uses the %s"), guessed.label, g.currencyLabel(g.currentCurrency))
+	}
+	return fmt.Sprintf(lang.X("game.currency.wrong_country", "Wrong! %s doesn't use the %s"), guessed.label, g.currencyLabel(g.currentCurrency))
+}
+
+func sortedKeys(currencies map[string]models.Currency) []string {
+	keys := make([]string, 0, len(currencies))
+	for code := range currencies {
+		keys = append(keys, code)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (g *Game) GetContent() *fyne.Container {
+	return g.content
+}
+
+func (g *Game) Start() {
+	g.newGame()
+}
+
+func (g *Game) Reset() {
+	g.score = 0
+	g.total = 0
+	g.gameProgress.Reset()
+	g.newGame()
+}
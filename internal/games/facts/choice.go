@@ -0,0 +1,127 @@
+package facts
+
+import (
+	"image/color"
+	"math/rand"
+
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/games/rounds"
+	"flagged-it/internal/ui/components"
+	"flagged-it/internal/utils"
+	"flagged-it/pkg/assets"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// optionCount is how many flags a multiple choice round offers
+const optionCount = 4
+
+// flagOption is one answer of a multiple choice round: a flag with the
+// country's name under it, on a button that is recoloured once revealed
+type flagOption struct {
+	country models.Country
+	button  *components.ColoredButton
+	flag    *canvas.Image
+	name    *widget.Label
+}
+
+func newFlagOption(tapped func(*flagOption)) *flagOption {
+	o := &flagOption{
+		flag: canvas.NewImageFromResource(nil),
+		name: widget.NewLabel(""),
+	}
+	o.button = components.NewColoredButton("", func() { tapped(o) })
+	o.flag.FillMode = canvas.ImageFillContain
+	o.flag.SetMinSize(fyne.NewSize(120, 80))
+	o.name.Alignment = fyne.TextAlignCenter
+	o.name.Wrapping = fyne.TextWrapWord
+	return o
+}
+
+// content stacks the flag and name over the button; neither takes taps, so
+// tapping anywhere on the option presses the button
+func (o *flagOption) content() fyne.CanvasObject {
+	return container.NewStack(o.button, container.NewPadded(container.NewVBox(o.flag, o.name)))
+}
+
+func (o *flagOption) set(country models.Country) {
+	o.country = country
+	o.flag.Resource = nil
+	if flagResource, err := assets.LoadFlagResource(country.CCA2); err == nil {
+		o.flag.Resource = flagResource
+	}
+	o.flag.Refresh()
+	o.name.SetText(utils.TranslateCountry(country))
+	o.button.SetBgColor(color.Transparent)
+	o.button.Button.Enable()
+}
+
+func (g *Game) setupChoices() {
+	g.choiceGrid = container.NewGridWithColumns(2)
+	g.options = make([]*flagOption, optionCount)
+	for i := range g.options {
+		g.options[i] = newFlagOption(g.pickOption)
+		g.choiceGrid.Add(g.options[i].content())
+	}
+	g.choiceGrid.Hide()
+}
+
+// showOptions deals the answer and three other countries with facts onto
+// the option buttons, in random order
+func (g *Game) showOptions() {
+	countries := rounds.Options(*g.currentCountry, g.pool, optionCount, nearbyFirst)
+	for i, option := range g.options {
+		if i < len(countries) {
+			option.set(countries[i])
+		}
+	}
+}
+
+// nearbyFirst prefers distractors from the answer's subregion, then its
+// region, so the flags aren't a giveaway
+func nearbyFirst(answer models.Country, pool []models.Country, n int) []models.Country {
+	var sameSubregion, sameRegion []models.Country
+	for _, country := range pool {
+		switch {
+		case country.CCA2 == answer.CCA2:
+			continue
+		case country.Subregion == answer.Subregion:
+			sameSubregion = append(sameSubregion, country)
+		case country.Region == answer.Region:
+			sameRegion = append(sameRegion, country)
+		}
+	}
+
+	var picked []models.Country
+	for _, tier := range [][]models.Country{sameSubregion, sameRegion} {
+		for _, i := range rand.Perm(len(tier)) {
+			if len(picked) == n {
+				return picked
+			}
+			picked = append(picked, tier[i])
+		}
+	}
+	return picked
+}
+
+func (g *Game) pickOption(option *flagOption) {
+	correct := option.country.CCA2 == g.currentCountry.CCA2
+	if !correct {
+		option.button.SetBgColor(components.WrongAnswerColor)
+		option.button.Disable()
+	}
+	g.submitGuess(option.name.Text, correct)
+}
+
+// revealOptions marks the answer and locks the options at the end of a round
+func (g *Game) revealOptions() {
+	for _, option := range g.options {
+		if g.currentCountry != nil && option.country.CCA2 == g.currentCountry.CCA2 {
+			option.button.SetBgColor(components.CorrectAnswerColor)
+		}
+		option.button.Disable()
+	}
+}
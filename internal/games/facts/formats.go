@@ -0,0 +1,61 @@
+package facts
+
+import (
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+// format is how the player answers a round
+type format int
+
+const (
+	// typed asks for the country's name
+	typed format = iota
+	// multipleChoice offers four flags to pick from
+	multipleChoice
+	// trueFalse pairs a fact with a country and asks whether they belong together
+	trueFalse
+)
+
+var formats = []format{typed, multipleChoice, trueFalse}
+
+func (f format) label() string {
+	switch f {
+	case multipleChoice:
+		return lang.X("game.facts.format_choice", "Multiple choice")
+	case trueFalse:
+		return lang.X("game.facts.format_true_false", "True or false")
+	default:
+		return lang.X("game.facts.format_typed", "Type the answer")
+	}
+}
+
+// variant names the format on the scoreboard; typing is the default and
+// left out
+func (f format) variant() string {
+	switch f {
+	case multipleChoice:
+		return "multiple choice"
+	case trueFalse:
+		return "true or false"
+	default:
+		return ""
+	}
+}
+
+func newFormatSelector(onChanged func(format)) *widget.RadioGroup {
+	labels := make([]string, len(formats))
+	for i, f := range formats {
+		labels[i] = f.label()
+	}
+	selector := widget.NewRadioGroup(labels, func(selected string) {
+		for i, label := range labels {
+			if label == selected {
+				onChanged(formats[i])
+			}
+		}
+	})
+	selector.Required = true
+	selector.SetSelected(labels[0])
+	return selector
+}
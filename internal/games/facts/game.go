@@ -3,15 +3,20 @@ package facts
 import (
 	"fmt"
 	"math/rand"
+	"net/url"
+	"sort"
 	"strings"
 	"time"
 
 	"flagged-it/internal/data"
 	"flagged-it/internal/data/models"
+	"flagged-it/internal/games/rounds"
+	"flagged-it/internal/games/survival"
 	"flagged-it/internal/ui/components"
 	"flagged-it/internal/utils"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/lang"
 	"fyne.io/fyne/v2/widget"
@@ -22,18 +27,29 @@ type GuessHistory struct {
 	Fact  string
 }
 
+const (
+	totalRounds = 5
+	// factsPerRound is how many facts, and so guesses, a round allows
+	factsPerRound = 3
+)
+
 type Game struct {
 	content          *fyne.Container
 	backFunc         func()
+	mainContent      *fyne.Container
+	selectionView    *fyne.Container
+	gameView         *fyne.Container
 	countries        []models.Country
 	factsData        map[string]models.CountryFacts
 	currentCountry   *models.Country
-	currentFacts     []string
+	currentFacts     []models.Fact // hardest first
 	currentFact      int
 	triesLeft        int
-	usedFacts        map[int]bool
 	guessHistory     []GuessHistory
-	factLabel        *widget.Label
+	factText         *widget.RichText
+	shownFact        string // the fact on screen, in markdown
+	sourcesBox       *fyne.Container
+	guessBar         *fyne.Container
 	guessEntry       *widget.Entry
 	statusLabel      *widget.Label
 	triesLabel       *widget.Label
@@ -41,8 +57,24 @@ type Game struct {
 	newGameBtn       *components.Button
 	historyContainer *fyne.Container
 	score            int
+	points           int // earned by guessing early, see roundPoints
 	total            int
 	gameProgress     *components.GameProgress
+	mode             components.PlayMode
+	session          *survival.Session
+	survivalBar      *fyne.Container
+	plan             *rounds.Plan
+	pool             []models.Country // countries with facts, the answers and options are drawn from
+	format           format
+	startTime        time.Time
+	choiceGrid       *fyne.Container
+	options          []*flagOption
+	trueFalseBar     *fyne.Container
+	pairingFlag      *canvas.Image
+	pairingName      *widget.Label
+	trueBtn          *components.Button
+	falseBtn         *components.Button
+	pairing          pairing
 }
 
 func NewGame(backFunc func()) *Game {
@@ -51,7 +83,6 @@ func NewGame(backFunc func()) *Game {
 	}
 	g.loadCountries()
 	g.setupUI()
-	g.newGame()
 	return g
 }
 
@@ -63,8 +94,48 @@ func (g *Game) loadCountries() {
 func (g *Game) setupUI() {
 	topBar := components.NewTopBar(lang.X("game.facts.title", "Guess by Facts"), g.backFunc, g.Reset)
 
-	g.factLabel = widget.NewLabel("")
-	g.factLabel.Wrapping = fyne.TextWrapWord
+	g.setupSelectionView()
+	g.setupGameView()
+
+	g.mainContent = container.NewMax(g.selectionView)
+
+	g.content = container.NewBorder(
+		topBar.GetContainer(), nil, nil, nil,
+		g.mainContent,
+	)
+}
+
+func (g *Game) setupSelectionView() {
+	titleLabel := widget.NewLabel(lang.X("game.flag.select_mode", "Select Mode"))
+	titleLabel.TextStyle.Bold = true
+
+	descLabel := widget.NewLabel(lang.X("game.facts.choose_mode", "Guess 5 countries from their facts, or survive as long as you can with 3 lives!"))
+	descLabel.Wrapping = fyne.TextWrapWord
+
+	modeSelector := components.NewPlayModeSelector([]components.PlayMode{components.ClassicMode, components.SurvivalMode}, func(mode components.PlayMode) {
+		g.mode = mode
+	})
+
+	formatSelector := newFormatSelector(func(f format) {
+		g.format = f
+	})
+
+	startBtn := components.NewButton(lang.X("game.higher_lower.start", "Start Game"), g.startGame)
+	startBtn.Importance = widget.HighImportance
+
+	g.selectionView = container.NewVBox(
+		titleLabel,
+		descLabel,
+		modeSelector,
+		formatSelector,
+		startBtn,
+	)
+}
+
+func (g *Game) setupGameView() {
+	g.factText = components.NewFactText("")
+	g.sourcesBox = container.NewVBox()
+	g.sourcesBox.Hide()
 
 	g.guessEntry = widget.NewEntry()
 	g.guessEntry.SetPlaceHolder(lang.X("game.facts.enter_country", "Enter country name..."))
@@ -74,11 +145,13 @@ func (g *Game) setupUI() {
 	g.statusLabel = widget.NewLabel("")
 	g.triesLabel = widget.NewLabel("")
 
-	guessContainer := container.NewBorder(
+	g.guessBar = container.NewBorder(
 		nil, nil,
 		g.guessBtn, nil,
 		g.guessEntry,
 	)
+	g.setupChoices()
+	g.setupTrueFalse()
 	g.historyContainer = container.NewVBox()
 
 	// Game progress component
@@ -88,22 +161,29 @@ func (g *Game) setupUI() {
 		ShowProgressBar: true,
 	})
 
+	// Lives and run length for survival mode
+	g.survivalBar = container.NewMax()
+	g.survivalBar.Hide()
+
 	// Header section with natural spacing
 	headerSection := container.NewVBox(
-		topBar.GetContainer(),
 		g.gameProgress.GetContainer(),
+		g.survivalBar,
 		g.statusLabel,
 		g.triesLabel,
 	)
 
 	// Game content
 	gameContent := container.NewVBox(
-		g.factLabel,
-		guessContainer,
+		g.factText,
+		g.sourcesBox,
+		g.guessBar,
+		g.choiceGrid,
+		g.trueFalseBar,
 		g.historyContainer,
 	)
 
-	g.content = container.NewVBox(
+	g.gameView = container.NewVBox(
 		headerSection,
 		gameContent,
 	)
@@ -115,58 +195,150 @@ func (g *Game) newGame() {
 		return
 	}
 
-	if g.total >= 5 {
-		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.facts.complete", "Game Complete! Final Score: %d/5 (%.0f%%)"), g.score, float64(g.score)/5*100))
-		g.guessEntry.Disable()
-		g.guessBtn.Disable()
+	if g.session == nil && g.total >= g.plan.Len() {
+		finalPercent := g.percent()
+		if g.plan.Len() == totalRounds {
+			g.statusLabel.SetText(fmt.Sprintf(lang.X("game.facts.complete", "Game Complete! Final Score: %d/5 (%.0f%%)"), g.score, finalPercent))
+		} else {
+			g.statusLabel.SetText(fmt.Sprintf(lang.X("game.complete_rounds", "Game Complete! Final Score: %d/%d (%.0f%%)"), g.score, g.plan.Len(), finalPercent))
+		}
+		g.triesLabel.SetText(fmt.Sprintf(lang.X("game.facts.points", "Points: %d/%d"), g.points, g.plan.Len()*factsPerRound))
+		utils.SaveScore(utils.ScoreEntry{
+			GameMode: "facts",
+			Score:    g.score,
+			Total:    g.plan.Len(),
+			Percent:  finalPercent,
+			Duration: int(time.Since(g.startTime).Seconds()),
+			Variant:  g.format.variant(),
+		})
+		g.setInputEnabled(false)
 		return
 	}
 
-	// Find countries that have facts available
-	var availableCountries []models.Country
-	for _, country := range g.countries {
-		if _, hasFacts := g.factsData[country.CCA2]; hasFacts {
-			availableCountries = append(availableCountries, country)
-		}
+	rand.Seed(time.Now().UnixNano())
+	if g.session != nil {
+		g.currentCountry = g.session.Next()
+	} else {
+		g.currentCountry = &g.plan.Rounds[g.total].Answer
 	}
+	g.currentFact = 0
+	g.triesLeft = factsPerRound
+	g.guessHistory = []GuessHistory{}
+	g.sourcesBox.Hide()
+	g.updateHistoryUI()
 
-	if len(availableCountries) == 0 {
-		g.statusLabel.SetText(lang.X("game.facts.no_facts", "No countries with facts available"))
+	if g.format == trueFalse {
+		g.showPairing()
+		g.updateStatus()
 		return
 	}
 
-	rand.Seed(time.Now().UnixNano())
-	g.currentCountry = &availableCountries[rand.Intn(len(availableCountries))]
-	g.currentFacts = g.factsData[g.currentCountry.CCA2].Facts
-	g.currentFact = 0
-	g.triesLeft = 3
-	g.usedFacts = make(map[int]bool)
-	g.guessHistory = []GuessHistory{}
+	g.currentFacts = orderFacts(g.factsData[g.currentCountry.CCA2].Facts)
 	g.guessEntry.SetText("")
-	g.guessEntry.Enable()
-	g.guessBtn.Enable()
-	g.updateHistoryUI()
+	if g.format == multipleChoice {
+		g.showOptions()
+	}
+	g.setInputEnabled(true)
 
 	g.showCurrentFact()
 	g.updateStatus()
 }
 
+// showInputs shows the answer controls of the chosen format
+func (g *Game) showInputs() {
+	g.guessBar.Hide()
+	g.choiceGrid.Hide()
+	g.trueFalseBar.Hide()
+	switch g.format {
+	case multipleChoice:
+		g.choiceGrid.Show()
+	case trueFalse:
+		g.trueFalseBar.Show()
+	default:
+		g.guessBar.Show()
+	}
+}
+
+// setInputEnabled locks or unlocks answering; the options are only locked,
+// since each round deals them afresh
+func (g *Game) setInputEnabled(enabled bool) {
+	if enabled {
+		g.guessEntry.Enable()
+		g.guessBtn.Enable()
+		return
+	}
+	g.guessEntry.Disable()
+	g.guessBtn.Disable()
+	g.trueBtn.Disable()
+	g.falseBtn.Disable()
+	g.revealOptions()
+}
+
+// orderFacts returns the facts hardest first, so a round opens with its
+// toughest clue. Facts of equal difficulty come in random order.
+func orderFacts(facts []models.Fact) []models.Fact {
+	ordered := make([]models.Fact, len(facts))
+	copy(ordered, facts)
+	rand.Shuffle(len(ordered), func(i, j int) { ordered[i], ordered[j] = ordered[j], ordered[i] })
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Difficulty > ordered[j].Difficulty })
+	return ordered
+}
+
 func (g *Game) showCurrentFact() {
-	if g.currentCountry != nil && len(g.usedFacts) < len(g.currentFacts) {
-		var factIndex int
-		for {
-			factIndex = rand.Intn(len(g.currentFacts))
-			if !g.usedFacts[factIndex] {
-				break
-			}
+	if g.currentCountry != nil && g.currentFact < len(g.currentFacts) {
+		g.shownFact = g.redact(g.currentFacts[g.currentFact].Text, *g.currentCountry)
+		g.factText.ParseMarkdown(fmt.Sprintf(lang.X("game.facts.fact_number", "Fact %d: %s"), g.currentFact+1, g.shownFact))
+	}
+}
+
+// redact hides the country's names from a fact, including those its facts
+// file lists for the current language
+func (g *Game) redact(text string, country models.Country) string {
+	facts := g.factsData[country.CCA2]
+	return utils.RedactCountry(text, country, append([]string{facts.Name}, facts.Redact...)...)
+}
+
+// roundPoints is what a correct guess on the current fact is worth: the
+// full factsPerRound on the first fact, one less for each fact after it
+func (g *Game) roundPoints() int {
+	return factsPerRound - g.currentFact
+}
+
+// percent is the share of the possible points earned in the rounds played
+func (g *Game) percent() float64 {
+	if g.total == 0 {
+		return 0
+	}
+	return float64(g.points) / float64(g.total*factsPerRound) * 100
+}
+
+// showSources lists the citations of the facts shown this round, so the
+// player can check the surprising ones
+func (g *Game) showSources() {
+	g.sourcesBox.RemoveAll()
+	shown := min(g.currentFact+1, len(g.currentFacts))
+	for i, fact := range g.currentFacts[:shown] {
+		if fact.Source == "" {
+			continue
 		}
-		g.usedFacts[factIndex] = true
-		fact := g.currentFacts[factIndex]
-		g.factLabel.SetText(fmt.Sprintf(lang.X("game.facts.fact_number", "Fact %d: %s"), g.currentFact+1, fact))
+		label := fmt.Sprintf(lang.X("game.facts.source", "Source for fact %d"), i+1)
+		if link, err := url.Parse(fact.Source); err == nil && link.Scheme != "" {
+			g.sourcesBox.Add(widget.NewHyperlink(label, link))
+		} else {
+			g.sourcesBox.Add(widget.NewLabel(fmt.Sprintf("%s: %s", label, fact.Source)))
+		}
+	}
+	if len(g.sourcesBox.Objects) > 0 {
+		g.sourcesBox.Show()
 	}
 }
 
 func (g *Game) updateStatus() {
+	if g.format == trueFalse {
+		g.statusLabel.SetText(lang.X("game.facts.true_or_false", "True or false?"))
+		g.triesLabel.SetText("")
+		return
+	}
 	g.statusLabel.SetText(lang.X("game.facts.guess_country", "Guess the country based on the fact!"))
 	g.triesLabel.SetText(fmt.Sprintf(lang.X("game.facts.tries_left", "Tries left: %d"), g.triesLeft))
 }
@@ -177,32 +349,35 @@ func (g *Game) makeGuess() {
 		return
 	}
 
-	if utils.MatchCountry(guess, *g.currentCountry, utils.MatchAll) {
-		currentFactText := g.factLabel.Text
+	g.submitGuess(guess, utils.MatchCountry(guess, *g.currentCountry, utils.MatchAll))
+}
+
+// submitGuess scores a typed or picked answer; a wrong one moves on to the
+// next, easier fact until the tries run out
+func (g *Game) submitGuess(guess string, correct bool) {
+	if correct {
 		g.guessHistory = append(g.guessHistory, GuessHistory{
 			Guess: fmt.Sprintf("%s ✅", guess),
-			Fact:  currentFactText,
+			Fact:  g.shownFact,
 		})
 		g.updateHistoryUI()
 
+		points := g.roundPoints()
 		g.total++
 		g.score++
-		g.gameProgress.UpdateProgress(g.total, 5, g.score)
+		g.points += points
+		g.gameProgress.UpdateProgressWithPercent(g.total, g.plan.Len(), g.percent())
 		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.facts.correct", "Correct! It was %s!"), g.currentCountry.Name.Common))
-		g.guessEntry.Disable()
-		g.guessBtn.Disable()
-		time.AfterFunc(1500*time.Millisecond, func() {
-			fyne.Do(func() {
-				g.newGame()
-			})
-		})
+		g.triesLabel.SetText(fmt.Sprintf(lang.X("game.facts.points_earned", "+%d points"), points))
+		g.setInputEnabled(false)
+		g.showSources()
+		g.finishRound(true)
 		return
 	}
 
-	currentFactText := g.factLabel.Text
 	g.guessHistory = append(g.guessHistory, GuessHistory{
 		Guess: guess,
-		Fact:  currentFactText,
+		Fact:  g.shownFact,
 	})
 	g.updateHistoryUI()
 
@@ -213,19 +388,15 @@ func (g *Game) makeGuess() {
 		flagEmoji := countryCodeToFlag(g.currentCountry.CCA2)
 		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.facts.game_over", "Game Over! It was %s %s"), g.currentCountry.Name.Common, flagEmoji))
 		g.total++
-		g.gameProgress.UpdateProgress(g.total, 5, g.score)
-		g.guessEntry.Disable()
-		g.guessBtn.Disable()
-		time.AfterFunc(1500*time.Millisecond, func() {
-			fyne.Do(func() {
-				g.newGame()
-			})
-		})
+		g.gameProgress.UpdateProgressWithPercent(g.total, g.plan.Len(), g.percent())
+		g.setInputEnabled(false)
+		g.showSources()
+		g.finishRound(false)
 		return
 	}
 
 	g.currentFact++
-	if g.currentFact < 3 && g.currentFact < len(g.currentFacts) {
+	if g.currentFact < factsPerRound && g.currentFact < len(g.currentFacts) {
 		g.showCurrentFact()
 		g.statusLabel.SetText(lang.X("game.facts.wrong_next", "Wrong! Try again with the next fact."))
 	} else {
@@ -234,12 +405,91 @@ func (g *Game) makeGuess() {
 	g.updateStatus()
 }
 
+// finishRound moves on to the next country, unless a survival run just ended
+func (g *Game) finishRound(correct bool) {
+	session := g.session
+	if session != nil && session.Answer(correct) {
+		g.statusLabel.SetText(g.statusLabel.Text + "\n" + session.GameOverText())
+		return
+	}
+
+	time.AfterFunc(1500*time.Millisecond, func() {
+		fyne.Do(func() {
+			// Ignore the timer if the player started a new game meanwhile
+			if g.session == session {
+				g.newGame()
+			}
+		})
+	})
+}
+
+// startGame starts a classic or survival game with the current mode
+func (g *Game) startGame() {
+	// Facts follow the language, which may have changed since the last game
+	g.factsData = data.LoadCountryFacts()
+	g.score = 0
+	g.points = 0
+	g.total = 0
+	g.startTime = time.Now()
+	g.gameProgress.Reset()
+	g.session = nil
+	g.survivalBar.Hide()
+	g.gameProgress.GetContainer().Show()
+
+	g.showInputs()
+	g.mainContent.RemoveAll()
+	g.mainContent.Add(g.gameView)
+	g.mainContent.Refresh()
+
+	// Only countries with facts can be asked about or offered as options;
+	// survival draws from all of them
+	g.pool = nil
+	for _, country := range g.countries {
+		if _, hasFacts := g.factsData[country.CCA2]; hasFacts {
+			g.pool = append(g.pool, country)
+		}
+	}
+	planRounds := totalRounds
+	if g.mode.Survival {
+		planRounds = 0
+	}
+	plan, err := rounds.New(g.pool, rounds.Config{Rounds: planRounds})
+	if err != nil {
+		g.plan = nil
+		g.setInputEnabled(false)
+		g.statusLabel.SetText(lang.X("game.facts.no_facts", "No countries with facts available"))
+		return
+	}
+	g.plan = plan
+
+	if g.mode.Survival {
+		var pool []models.Country
+		for _, round := range plan.Rounds {
+			pool = append(pool, round.Answer)
+		}
+		g.session = survival.NewSession("facts", "", pool)
+		g.survivalBar.RemoveAll()
+		g.survivalBar.Add(g.session.GetContainer())
+		g.survivalBar.Show()
+		g.gameProgress.GetContainer().Hide()
+	}
+
+	g.newGame()
+}
+
+func (g *Game) showSelection() {
+	g.session = nil
+	g.mainContent.RemoveAll()
+	g.mainContent.Add(g.selectionView)
+	g.mainContent.Refresh()
+}
+
 func (g *Game) GetContent() *fyne.Container {
 	return g.content
 }
 
 func (g *Game) Start() {
-	g.newGame()
+	g.showSelection()
 }
 
 func (g *Game) updateHistoryUI() {
@@ -263,21 +513,12 @@ func (g *Game) updateHistoryUI() {
 		guessHeader := widget.NewLabel(fmt.Sprintf(lang.X("game.facts.guess_number", "Guess %d: %s"), i+1, guessText))
 		guessHeader.TextStyle.Bold = true
 
-		// Create fact text (remove "Fact X:" prefix for cleaner display)
-		factText := history.Fact
-		if strings.Contains(factText, ": ") {
-			parts := strings.SplitN(factText, ": ", 2)
-			if len(parts) == 2 {
-				factText = parts[1]
-			}
-		}
-		factLabel := widget.NewLabel(fmt.Sprintf(lang.X("game.facts.fact_label", "Fact: %s"), factText))
-		factLabel.Wrapping = fyne.TextWrapWord
+		factText := components.NewFactText(fmt.Sprintf(lang.X("game.facts.fact_label", "Fact: %s"), history.Fact))
 
 		// Create a card-like container for each guess
 		guessCard := container.NewVBox(
 			guessHeader,
-			factLabel,
+			factText,
 		)
 
 		g.historyContainer.Add(guessCard)
@@ -290,10 +531,7 @@ func (g *Game) updateHistoryUI() {
 }
 
 func (g *Game) Reset() {
-	g.score = 0
-	g.total = 0
-	g.gameProgress.Reset()
-	g.newGame()
+	g.showSelection()
 }
 
 func countryCodeToFlag(code string) string {
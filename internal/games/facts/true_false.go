@@ -0,0 +1,100 @@
+package facts
+
+import (
+	"fmt"
+	"math/rand"
+
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/games/rounds"
+	"flagged-it/internal/ui/components"
+	"flagged-it/internal/utils"
+	"flagged-it/pkg/assets"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+// pairing is a true or false question: a fact shown next to the round's
+// country, which is either its own or a neighbour's
+type pairing struct {
+	owner   models.Country
+	fact    models.Fact
+	matches bool
+}
+
+func (g *Game) setupTrueFalse() {
+	g.pairingFlag = canvas.NewImageFromResource(nil)
+	g.pairingFlag.FillMode = canvas.ImageFillContain
+	g.pairingFlag.SetMinSize(fyne.NewSize(90, 60))
+	g.pairingName = widget.NewLabel("")
+	g.pairingName.TextStyle.Bold = true
+
+	g.trueBtn = components.NewButton(lang.X("game.facts.true", "True"), func() { g.answerPairing(true) })
+	g.falseBtn = components.NewButton(lang.X("game.facts.false", "False"), func() { g.answerPairing(false) })
+
+	g.trueFalseBar = container.NewVBox(
+		container.NewHBox(g.pairingFlag, g.pairingName),
+		container.NewGridWithColumns(2, g.trueBtn, g.falseBtn),
+	)
+	g.trueFalseBar.Hide()
+}
+
+// newPairing matches the country with one of its facts half the time, and
+// otherwise with a fact about a country nearby, so the wrong pairings are
+// plausible
+func (g *Game) newPairing(country models.Country) pairing {
+	p := pairing{owner: country, matches: true}
+	if rand.Intn(2) == 0 {
+		for _, other := range rounds.Options(country, g.pool, 2, nearbyFirst) {
+			if other.CCA2 != country.CCA2 {
+				p.owner = other
+				p.matches = false
+			}
+		}
+	}
+	facts := g.factsData[p.owner.CCA2].Facts
+	p.fact = facts[rand.Intn(len(facts))]
+	return p
+}
+
+// showPairing asks about the round's country. Both countries' names are
+// redacted from the fact, so a name can't settle the question either way.
+func (g *Game) showPairing() {
+	g.pairing = g.newPairing(*g.currentCountry)
+	g.currentFacts = []models.Fact{g.pairing.fact}
+
+	g.pairingFlag.Resource = nil
+	if flagResource, err := assets.LoadFlagResource(g.currentCountry.CCA2); err == nil {
+		g.pairingFlag.Resource = flagResource
+	}
+	g.pairingFlag.Refresh()
+	g.pairingName.SetText(fmt.Sprintf(lang.X("game.facts.belongs_to", "Is this fact about %s?"), utils.TranslateCountry(*g.currentCountry)))
+
+	g.shownFact = g.redact(g.redact(g.pairing.fact.Text, g.pairing.owner), *g.currentCountry)
+	g.factText.ParseMarkdown(g.shownFact)
+	g.trueBtn.Enable()
+	g.falseBtn.Enable()
+}
+
+func (g *Game) answerPairing(said bool) {
+	g.trueBtn.Disable()
+	g.falseBtn.Disable()
+
+	correct := said == g.pairing.matches
+	owner := utils.TranslateCountry(g.pairing.owner)
+	g.total++
+	if correct {
+		g.score++
+		g.points += factsPerRound
+		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.facts.pairing_correct", "Correct! This fact is about %s."), owner))
+	} else {
+		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.facts.pairing_wrong", "Wrong! This fact is about %s."), owner))
+	}
+	g.triesLabel.SetText("")
+	g.gameProgress.UpdateProgressWithPercent(g.total, g.plan.Len(), g.percent())
+	g.showSources()
+	g.finishRound(correct)
+}
@@ -0,0 +1,81 @@
+package flag
+
+import (
+	"math/rand"
+
+	"flagged-it/internal/data"
+	"flagged-it/internal/data/models"
+)
+
+type difficulty int
+
+const (
+	easy difficulty = iota
+	medium
+	hard
+)
+
+// hardPoolSize is how many of the closest lookalikes hard mode picks from,
+// so the same flag doesn't always come with the same three options
+const hardPoolSize = 5
+
+// pickDistractors returns up to n preferred wrong options for answer. Easy
+// leaves everything to the round planner's random pick, medium prefers the
+// same subregion and hard prefers the flags that look most alike. Each tier
+// is topped up by the next easier one.
+func (g *Game) pickDistractors(answer models.Country, pool []models.Country, n int) []models.Country {
+	var tiers [][]models.Country
+
+	switch g.difficulty {
+	case hard:
+		tiers = append(tiers, g.lookalikes(answer))
+		fallthrough
+	case medium:
+		var sameSubregion, sameRegion []models.Country
+		for _, country := range pool {
+			switch {
+			case country.Subregion == answer.Subregion:
+				sameSubregion = append(sameSubregion, country)
+			case country.Region == answer.Region:
+				sameRegion = append(sameRegion, country)
+			}
+		}
+		tiers = append(tiers, sameSubregion, sameRegion)
+	}
+
+	used := map[string]bool{answer.CCA2: true}
+	var picked []models.Country
+	for _, tier := range tiers {
+		for _, i := range rand.Perm(len(tier)) {
+			if len(picked) == n {
+				return picked
+			}
+			if !used[tier[i].CCA2] {
+				used[tier[i].CCA2] = true
+				picked = append(picked, tier[i])
+			}
+		}
+	}
+	return picked
+}
+
+// lookalikes returns the closest looking flags from the embedded similarity
+// table. They may come from outside the selected region, since a lookalike
+// from elsewhere is exactly what makes the question hard.
+func (g *Game) lookalikes(answer models.Country) []models.Country {
+	byCode := make(map[string]models.Country, len(g.allCountries))
+	for _, country := range g.allCountries {
+		byCode[country.CCA2] = country
+	}
+
+	var similar []models.Country
+	for _, code := range data.LoadFlagSimilarity()[answer.CCA2] {
+		if len(similar) == hardPoolSize {
+			break
+		}
+		if country, ok := byCode[code]; ok {
+			similar = append(similar, country)
+		}
+	}
+	return similar
+}
@@ -2,13 +2,13 @@ package flag
 
 import (
 	"fmt"
-	"image/color"
-	"math/rand"
-	"runtime"
+	"strings"
 	"time"
 
 	"flagged-it/internal/data"
 	"flagged-it/internal/data/models"
+	"flagged-it/internal/games/rounds"
+	"flagged-it/internal/games/survival"
 	"flagged-it/internal/ui/components"
 	"flagged-it/internal/utils"
 	"flagged-it/pkg/assets"
@@ -16,65 +16,56 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/lang"
 	"fyne.io/fyne/v2/widget"
 )
 
-type coloredButton struct {
-	widget.BaseWidget
-	button *widget.Button
-	rect   *canvas.Rectangle
-}
+// defaultRounds is the classic game length; roundCounts are the lengths
+// offered in the region selector, where 0 plays every flag in the region
+const defaultRounds = 10
 
-func (c *coloredButton) CreateRenderer() fyne.WidgetRenderer {
-	return widget.NewSimpleRenderer(container.NewStack(
-		c.rect,
-		c.button,
-	))
-}
-
-func (c *coloredButton) SetBgColor(col color.Color) {
-	c.rect.FillColor = col
-	c.Refresh()
-}
-
-func (c *coloredButton) Cursor() desktop.Cursor {
-	return desktop.PointerCursor
-}
-
-func (c *coloredButton) Tapped(e *fyne.PointEvent) {
-	c.button.Tapped(e)
-}
+var roundCounts = []int{defaultRounds, 20, 0}
 
 type Game struct {
 	content        *fyne.Container
 	backFunc       func()
+	mainContent    *fyne.Container
+	selectionView  *fyne.Container
+	regionSelector *components.RegionSelector
+	gameView       *fyne.Container
 	countries      []models.Country
 	allCountries   []models.Country // Keep all countries for options
 	currentCountry *models.Country
-	usedCountries  map[string]bool
+	plan           *rounds.Plan
 	options        []models.Country
 	flagImage      *canvas.Image
 	statusLabel    *widget.Label
-	buttons        []*widget.Button
-	coloredButtons []*coloredButton
+	buttons        []*components.ColoredButton
 	buttonGrid     *fyne.Container
 	score          int
 	total          int
 	scoreLabel     *widget.Label
 	selectedRegion string
 	gameProgress   *components.GameProgress
+	mode           components.PlayMode
+	countdown      *components.Countdown
+	blitzBar       *fyne.Container
+	session        *survival.Session
+	survivalBar    *fyne.Container
+	typed          bool
+	difficulty     difficulty
+	roundCount     int
+	typedPanel     *typedPanel
+	halfCredits    int // correct answers given after revealing the options
 }
 
 func NewGame(backFunc func()) *Game {
 	g := &Game{
-		backFunc:      backFunc,
-		usedCountries: make(map[string]bool),
+		backFunc:   backFunc,
+		roundCount: defaultRounds,
 	}
 	g.loadCountries()
 	g.setupUI()
-	g.newGame()
 	return g
 }
 
@@ -83,7 +74,7 @@ func (g *Game) loadCountries() {
 	g.countries = g.allCountries
 }
 
-// SetRegion filters countries by region
+// SetRegion filters countries by region or subregion and starts a game
 func (g *Game) SetRegion(region string) {
 	g.selectedRegion = region
 	if region == "" || region == "World" {
@@ -91,16 +82,118 @@ func (g *Game) SetRegion(region string) {
 	} else {
 		g.countries = []models.Country{}
 		for _, country := range g.allCountries {
-			if country.Region == region {
+			if country.Region == region || country.Subregion == region {
 				g.countries = append(g.countries, country)
 			}
 		}
 	}
-	g.Reset()
+	g.startGame()
+}
+
+// PreselectRegion opens the settings with a region suggested, so the mode,
+// answer and difficulty can still be picked before it starts
+func (g *Game) PreselectRegion(region string) {
+	g.regionSelector.Highlight(region)
+	g.showSelection()
 }
 
 func (g *Game) setupUI() {
-	topBar := components.NewTopBar(lang.X("game.flag.title", "Guess by Flag"), g.backFunc, g.Reset)
+	topBar := components.NewTopBar(lang.X("game.flag.title", "Guess by Flag"), g.goBack, g.Reset)
+
+	g.setupSelectionView()
+	g.setupGameView()
+
+	g.mainContent = container.NewMax(g.selectionView)
+
+	g.content = container.NewBorder(
+		topBar.GetContainer(), nil, nil, nil,
+		g.mainContent,
+	)
+}
+
+func (g *Game) setupSelectionView() {
+	titleLabel := widget.NewLabel(lang.X("game.flag.select_mode", "Select Mode"))
+	titleLabel.TextStyle.Bold = true
+
+	descLabel := widget.NewLabel(lang.X("game.flag.choose_mode", "Play a set number of rounds, name as many flags as you can before the time runs out, or survive as long as you can with 3 lives!"))
+	descLabel.Wrapping = fyne.TextWrapWord
+
+	modes := append([]components.PlayMode{components.ClassicMode}, components.BlitzModes()...)
+	modes = append(modes, components.SurvivalMode)
+	modeSelector := components.NewPlayModeSelector(modes, func(mode components.PlayMode) {
+		g.mode = mode
+	})
+
+	answerLabels := []string{
+		lang.X("game.flag.answer_choice", "Multiple choice"),
+		lang.X("game.flag.answer_typed", "Type the answer"),
+	}
+	answerSelector := widget.NewRadioGroup(answerLabels, func(selected string) {
+		g.typed = selected == answerLabels[1]
+	})
+	answerSelector.Horizontal = true
+	answerSelector.Required = true
+	answerSelector.SetSelected(answerLabels[0])
+
+	difficultyLabels := []string{
+		lang.X("difficulty.easy", "Easy"),
+		lang.X("difficulty.medium", "Medium"),
+		lang.X("difficulty.hard", "Hard"),
+	}
+	difficultySelector := widget.NewRadioGroup(difficultyLabels, func(selected string) {
+		for i, label := range difficultyLabels {
+			if label == selected {
+				g.difficulty = difficulty(i)
+			}
+		}
+	})
+	difficultySelector.Horizontal = true
+	difficultySelector.Required = true
+	difficultySelector.SetSelected(difficultyLabels[0])
+
+	regions, subregions := g.getAvailableRegions()
+	g.regionSelector = components.NewRegionSelectorWithConfig(components.RegionSelectorConfig{
+		Title:       lang.X("game.flag.select_region", "Select Region"),
+		Description: lang.X("game.flag.choose_region", "Pick a region or subregion to start. Tap World to play every flag."),
+		Regions:     regions,
+		Subregions:  subregions,
+		RoundCounts: roundCounts,
+		OnRoundCount: func(count int) {
+			g.roundCount = count
+		},
+		OnRegionSelected: g.SetRegion,
+	})
+
+	// The settings and the region list don't fit on small screens
+	g.selectionView = container.NewMax(container.NewVScroll(container.NewVBox(
+		titleLabel,
+		descLabel,
+		modeSelector,
+		answerSelector,
+		difficultySelector,
+		g.regionSelector.GetContainer(),
+	)))
+}
+
+// getAvailableRegions returns the regions and the subregions within each
+func (g *Game) getAvailableRegions() ([]string, map[string][]string) {
+	regions := []string{"World"}
+	subregions := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, country := range g.allCountries {
+		if country.Region != "" && !seen[country.Region] {
+			seen[country.Region] = true
+			regions = append(regions, country.Region)
+		}
+		if country.Subregion != "" && !seen[country.Subregion] {
+			seen[country.Subregion] = true
+			subregions[country.Region] = append(subregions[country.Region], country.Subregion)
+		}
+	}
+	return regions, subregions
+}
+
+func (g *Game) setupGameView() {
 
 	g.flagImage = canvas.NewImageFromResource(nil)
 	g.flagImage.FillMode = canvas.ImageFillContain
@@ -127,20 +220,36 @@ func (g *Game) setupUI() {
 		ShowProgressBar: true,
 	})
 
+	// Countdown and running score for blitz mode
+	g.countdown = components.NewCountdown()
+	g.countdown.OnExpired = g.endBlitz
+	g.scoreLabel = widget.NewLabel("")
+	g.blitzBar = container.NewBorder(nil, nil, g.scoreLabel, g.countdown.GetContainer())
+	g.blitzBar.Hide()
+
+	// Lives and run length for survival mode
+	g.survivalBar = container.NewMax()
+	g.survivalBar.Hide()
+
 	// Header section (fixed at top)
 	headerSection := container.NewVBox(
-		topBar.GetContainer(),
 		g.gameProgress.GetContainer(),
+		g.blitzBar,
+		g.survivalBar,
 		g.statusLabel,
 	)
 
+	// Text entry and hints for the typed variant
+	g.typedPanel = newTypedPanel(g)
+
 	// Footer section (fixed at bottom)
 	footerSection := container.NewVBox(
+		g.typedPanel.GetContainer(),
 		g.buttonGrid,
 	)
 
 	// Use Border layout to properly constrain content
-	g.content = container.NewBorder(
+	g.gameView = container.NewBorder(
 		headerSection, // top
 		footerSection, // bottom
 		nil, nil,
@@ -154,47 +263,35 @@ func (g *Game) newGame() {
 		return
 	}
 
-	rand.Seed(time.Now().UnixNano())
-	var newCountry *models.Country
-	for {
-		newCountry = &g.countries[rand.Intn(len(g.countries))]
-		if !g.usedCountries[newCountry.CCA2] {
-			break
-		}
-	}
-	g.usedCountries[newCountry.CCA2] = true
-	g.currentCountry = newCountry
-
-	g.options = []models.Country{*g.currentCountry}
-	usedOptions := make(map[string]bool)
-	usedOptions[g.currentCountry.CCA2] = true
-	for len(g.options) < 4 {
-		option := g.countries[rand.Intn(len(g.countries))]
-		if !usedOptions[option.CCA2] {
-			g.options = append(g.options, option)
-			usedOptions[option.CCA2] = true
+	if g.session != nil {
+		// Survival picks countries by difficulty
+		g.currentCountry = g.session.Next()
+		g.options = rounds.Options(*g.currentCountry, g.countries, g.plan.Options, g.pickDistractors)
+	} else {
+		if g.total >= g.plan.Len() {
+			// Blitz runs can outlast the pool; plan another pass
+			g.plan.Extend()
 		}
+		round := g.plan.Rounds[g.total]
+		g.currentCountry = &round.Answer
+		g.options = round.Options
 	}
 
-	rand.Shuffle(len(g.options), func(i, j int) {
-		g.options[i], g.options[j] = g.options[j], g.options[i]
-	})
-
 	g.displayFlag()
 	g.createButtons()
-	g.statusLabel.SetText(lang.X("game.flag.question", "Which country does this flag belong to?"))
+	if g.typed {
+		g.buttonGrid.Hide()
+		g.typedPanel.reset()
+	}
+	question := lang.X("game.flag.question", "Which country does this flag belong to?")
+	if notice := g.plan.Notice(); notice != "" && g.total == 0 {
+		question = notice + "\n" + question
+	}
+	g.statusLabel.SetText(question)
 }
 
 func (g *Game) displayFlag() {
-	var flagResource fyne.Resource
-	var err error
-	if runtime.GOOS == "js" {
-		flagURL := fmt.Sprintf("assets/twemoji_flags_cca2/%s.svg", g.currentCountry.CCA2)
-		flagResource, err = fyne.LoadResourceFromURLString(flagURL)
-	} else {
-		flagPath := fmt.Sprintf("assets/twemoji_flags_cca2/%s.svg", g.currentCountry.CCA2)
-		flagResource, err = assets.LoadResourceFromPath(flagPath)
-	}
+	flagResource, err := assets.LoadFlagResource(g.currentCountry.CCA2)
 	if err == nil {
 		g.flagImage.Resource = flagResource
 	}
@@ -204,29 +301,44 @@ func (g *Game) displayFlag() {
 func (g *Game) createButtons() {
 	g.buttonGrid.RemoveAll()
 
-	g.buttons = make([]*widget.Button, 4)
-	g.coloredButtons = make([]*coloredButton, 4)
+	g.buttons = make([]*components.ColoredButton, len(g.options))
 	for i, country := range g.options {
 		country := country
-		btn := widget.NewButtonWithIcon(country.Name.Common, nil, func() {
+		btn := components.NewColoredButton(country.Name.Common, func() {
 			g.makeGuess(country)
 		})
-		btn.Importance = widget.LowImportance
 		g.buttons[i] = btn
-
-		rect := canvas.NewRectangle(color.Transparent)
-
-		colored := &coloredButton{button: btn, rect: rect}
-		colored.ExtendBaseWidget(colored)
-		g.coloredButtons[i] = colored
-		g.buttonGrid.Add(colored)
+		g.buttonGrid.Add(btn)
 	}
 	g.buttonGrid.Refresh()
 }
 
 func (g *Game) makeGuess(guessed models.Country) {
+	g.answer(guessed.CCA2 == g.currentCountry.CCA2)
+}
+
+// answer scores the current flag; both the option buttons and the typed
+// entry end up here so every play mode works with either answer style
+func (g *Game) answer(isCorrect bool) {
+	if g.typed {
+		g.typedPanel.disable()
+		// Show the options so the revealed answer is highlighted
+		g.buttonGrid.Show()
+	}
+	if isCorrect && g.typed && g.typedPanel.usedOptions {
+		g.halfCredits++
+	}
+
+	if g.mode.IsBlitz() {
+		g.makeBlitzGuess(isCorrect)
+		return
+	}
+	if g.session != nil {
+		g.makeSurvivalGuess(isCorrect)
+		return
+	}
+
 	g.total++
-	isCorrect := guessed.CCA2 == g.currentCountry.CCA2
 	if isCorrect {
 		g.score++
 		g.statusLabel.SetText(lang.L("game.correct", map[string]any{"Country": g.currentCountry.Name.Common}))
@@ -235,34 +347,30 @@ func (g *Game) makeGuess(guessed models.Country) {
 	}
 
 	// Update progress display
-	g.gameProgress.UpdateProgress(g.total, 10, g.score)
+	g.gameProgress.UpdateProgressWithPercent(g.total, g.plan.Len(), g.percent())
 
-	for i, btn := range g.buttons {
-		var bgColor color.Color
-		if btn.Text == g.currentCountry.Name.Common {
-			bgColor = color.RGBA{30, 180, 80, 255}
-		} else {
-			bgColor = color.RGBA{255, 99, 71, 255}
-		}
-		g.coloredButtons[i].SetBgColor(bgColor)
-		btn.Disable()
-	}
+	g.revealAnswer()
+
+	if g.total >= g.plan.Len() {
+		finalPercent := g.percent()
 
-	if g.total >= 10 {
-		finalPercent := float64(g.score) / 10 * 100
-		
 		// Save score to scoreboard
 		utils.SaveScore(utils.ScoreEntry{
 			GameMode: "flag",
 			Score:    g.score,
-			Total:    10,
+			Total:    g.plan.Len(),
 			Percent:  finalPercent,
 			Region:   g.selectedRegion,
+			Variant:  g.variant(g.roundsVariant()),
 		})
-		
+
 		time.AfterFunc(1500*time.Millisecond, func() {
 			fyne.Do(func() {
-				g.statusLabel.SetText(lang.L("game.complete", map[string]any{"Score": g.score, "Percent": int(finalPercent)}))
+				if g.plan.Len() == defaultRounds {
+					g.statusLabel.SetText(lang.L("game.complete", map[string]any{"Score": g.score, "Percent": int(finalPercent)}))
+				} else {
+					g.statusLabel.SetText(fmt.Sprintf(lang.X("game.complete_rounds", "Game Complete! Final Score: %d/%d (%.0f%%)"), g.score, g.plan.Len(), finalPercent))
+				}
 			})
 		})
 	} else {
@@ -274,18 +382,215 @@ func (g *Game) makeGuess(guessed models.Country) {
 	}
 }
 
-func (g *Game) GetContent() *fyne.Container {
-	return g.content
+// percent is the share of points earned, where answers picked after
+// revealing the options in the typed variant are worth half a point
+func (g *Game) percent() float64 {
+	if g.total == 0 {
+		return 0
+	}
+	points := float64(g.score) - float64(g.halfCredits)/2
+	return points / float64(g.total) * 100
 }
 
-func (g *Game) Start() {
-	g.newGame()
+// variant describes the settings for the scoreboard, e.g. "60s typed hard"
+func (g *Game) variant(timeLimit string) string {
+	var parts []string
+	if timeLimit != "" {
+		parts = append(parts, timeLimit)
+	}
+	if g.typed {
+		parts = append(parts, "typed")
+	}
+	switch g.difficulty {
+	case medium:
+		parts = append(parts, "medium")
+	case hard:
+		parts = append(parts, "hard")
+	}
+	return strings.Join(parts, " ")
 }
 
-func (g *Game) Reset() {
+// roundsVariant labels non-default game lengths so scores are only compared
+// against games of the same length
+func (g *Game) roundsVariant() string {
+	switch g.roundCount {
+	case defaultRounds:
+		return ""
+	case 0:
+		return "all"
+	}
+	return fmt.Sprintf("%d rounds", g.roundCount)
+}
+
+// revealAnswer colours the option buttons and disables them
+func (g *Game) revealAnswer() {
+	for _, btn := range g.buttons {
+		if btn.Button.Text == g.currentCountry.Name.Common {
+			btn.SetBgColor(components.CorrectAnswerColor)
+		} else {
+			btn.SetBgColor(components.WrongAnswerColor)
+		}
+		btn.Disable()
+	}
+}
+
+// makeBlitzGuess scores a blitz answer and moves straight to the next flag;
+// wrong answers cost time instead of a round
+func (g *Game) makeBlitzGuess(isCorrect bool) {
+	if !g.countdown.Running() {
+		return
+	}
+
+	g.total++
+	if isCorrect {
+		g.score++
+		correctCountry := g.currentCountry.Name.Common
+		g.newGame()
+		g.statusLabel.SetText(lang.L("game.correct", map[string]any{"Country": correctCountry}))
+	} else {
+		wrongCountry := g.currentCountry.Name.Common
+		g.countdown.Penalize(components.BlitzPenalty)
+		if !g.countdown.Running() {
+			// The penalty ran the clock out and ended the game
+			return
+		}
+		g.newGame()
+		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.blitz.penalty", "Wrong! It was %s (-%ds)"), wrongCountry, int(components.BlitzPenalty.Seconds())))
+	}
+	g.scoreLabel.SetText(fmt.Sprintf(lang.X("game.blitz.score", "Correct: %d"), g.score))
+}
+
+// makeSurvivalGuess reveals the answer and either continues the run or ends it
+func (g *Game) makeSurvivalGuess(isCorrect bool) {
+	if isCorrect {
+		g.statusLabel.SetText(lang.L("game.correct", map[string]any{"Country": g.currentCountry.Name.Common}))
+	} else {
+		g.statusLabel.SetText(lang.L("game.wrong", map[string]any{"Country": g.currentCountry.Name.Common}))
+	}
+
+	g.revealAnswer()
+
+	session := g.session
+	if session.Answer(isCorrect) {
+		g.statusLabel.SetText(g.statusLabel.Text + "\n" + session.GameOverText())
+		return
+	}
+
+	time.AfterFunc(1500*time.Millisecond, func() {
+		fyne.Do(func() {
+			// Ignore the timer if the player started a new game meanwhile
+			if g.session == session {
+				g.newGame()
+			}
+		})
+	})
+}
+
+// endBlitz is called when the countdown runs out
+func (g *Game) endBlitz() {
+	if g.typed {
+		g.typedPanel.disable()
+		g.buttonGrid.Show()
+	}
+	for _, btn := range g.buttons {
+		if btn.Button.Text == g.currentCountry.Name.Common {
+			btn.SetBgColor(components.CorrectAnswerColor)
+		}
+		btn.Disable()
+	}
+
+	utils.SaveScore(utils.ScoreEntry{
+		GameMode: "flag_blitz",
+		Score:    g.score,
+		Total:    g.total,
+		Percent:  g.percent(),
+		Duration: int(g.countdown.Elapsed().Seconds()),
+		Region:   g.selectedRegion,
+		Variant:  g.variant(fmt.Sprintf("%ds", int(g.mode.TimeLimit.Seconds()))),
+	})
+
+	g.statusLabel.SetText(fmt.Sprintf(lang.X("game.blitz.complete", "Time's up! You got %d of %d flags right."), g.score, g.total))
+}
+
+// startGame starts a classic or blitz game with the current settings
+func (g *Game) startGame() {
 	g.score = 0
 	g.total = 0
-	g.usedCountries = make(map[string]bool)
+	g.halfCredits = 0
 	g.gameProgress.Reset()
+	g.countdown.Stop()
+	g.session = nil
+	g.gameProgress.GetContainer().Hide()
+	g.blitzBar.Hide()
+	g.survivalBar.Hide()
+	g.buttonGrid.Show()
+	g.typedPanel.GetContainer().Hide()
+
+	g.mainContent.RemoveAll()
+	g.mainContent.Add(g.gameView)
+	g.mainContent.Refresh()
+
+	// Endless modes plan one pass over the pool and extend it as needed
+	planRounds := g.roundCount
+	if g.mode != components.ClassicMode {
+		planRounds = 0
+	}
+	plan, err := rounds.New(g.countries, rounds.Config{
+		Rounds:      planRounds,
+		Options:     4,
+		Distractors: g.pickDistractors,
+	})
+	if err != nil {
+		g.plan = nil
+		g.buttonGrid.RemoveAll()
+		g.flagImage.Resource = nil
+		g.flagImage.Refresh()
+		g.statusLabel.SetText(rounds.ErrorText(err))
+		return
+	}
+	g.plan = plan
+	if g.typed {
+		g.typedPanel.GetContainer().Show()
+	}
+
+	switch {
+	case g.mode.IsBlitz():
+		g.blitzBar.Show()
+		g.scoreLabel.SetText(fmt.Sprintf(lang.X("game.blitz.score", "Correct: %d"), 0))
+		g.countdown.Start(g.mode.TimeLimit)
+	case g.mode.Survival:
+		g.session = survival.NewSession("flag", g.selectedRegion, g.countries)
+		g.survivalBar.RemoveAll()
+		g.survivalBar.Add(g.session.GetContainer())
+		g.survivalBar.Show()
+	default:
+		g.gameProgress.GetContainer().Show()
+	}
+
 	g.newGame()
 }
+
+func (g *Game) showSelection() {
+	g.countdown.Stop()
+	g.mainContent.RemoveAll()
+	g.mainContent.Add(g.selectionView)
+	g.mainContent.Refresh()
+}
+
+// goBack stops a running blitz countdown before leaving the game
+func (g *Game) goBack() {
+	g.countdown.Stop()
+	g.backFunc()
+}
+
+func (g *Game) GetContent() *fyne.Container {
+	return g.content
+}
+
+func (g *Game) Start() {
+	g.showSelection()
+}
+
+func (g *Game) Reset() {
+	g.showSelection()
+}
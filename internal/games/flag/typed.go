@@ -0,0 +1,147 @@
+package flag
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"flagged-it/internal/ui/components"
+	"flagged-it/internal/utils"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+// typedPanel is the text entry and hint buttons for the typed variant of
+// the flag game. The four options are still built for every round and
+// only shown when the player asks for them.
+type typedPanel struct {
+	game        *Game
+	content     *fyne.Container
+	entry       *widget.Entry
+	guessBtn    *components.Button
+	firstBtn    *components.Button
+	lengthBtn   *components.Button
+	optionsBtn  *components.Button
+	hintLabel   *widget.Label
+	showFirst   bool
+	showLength  bool
+	usedOptions bool
+}
+
+func newTypedPanel(g *Game) *typedPanel {
+	p := &typedPanel{game: g}
+
+	p.entry = widget.NewEntry()
+	p.entry.SetPlaceHolder(lang.X("game.shape.enter_country", "Enter country name..."))
+	p.entry.OnSubmitted = p.submit
+
+	p.guessBtn = components.NewButton(lang.X("game.shape.guess", "Guess"), func() { p.submit(p.entry.Text) })
+	p.guessBtn.Importance = widget.HighImportance
+
+	p.firstBtn = components.NewButton(lang.X("game.flag.hint_first_letter", "First letter"), func() {
+		p.showFirst = true
+		p.firstBtn.Disable()
+		p.updateHint()
+	})
+	p.lengthBtn = components.NewButton(lang.X("game.flag.hint_length", "Letter count"), func() {
+		p.showLength = true
+		p.lengthBtn.Disable()
+		p.updateHint()
+	})
+	p.optionsBtn = components.NewButton(lang.X("game.flag.hint_options", "Show 4 options (½ point)"), p.revealOptions)
+
+	p.hintLabel = widget.NewLabel("")
+	p.hintLabel.Alignment = fyne.TextAlignCenter
+	p.hintLabel.TextStyle.Monospace = true
+
+	columns := 3
+	if utils.IsMobile() {
+		columns = 1
+	}
+	p.content = container.NewVBox(
+		p.hintLabel,
+		container.NewBorder(nil, nil, nil, p.guessBtn, p.entry),
+		container.NewGridWithColumns(columns, p.firstBtn, p.lengthBtn, p.optionsBtn),
+	)
+	p.content.Hide()
+	return p
+}
+
+// reset clears the entry and hints for a new flag
+func (p *typedPanel) reset() {
+	p.showFirst = false
+	p.showLength = false
+	p.usedOptions = false
+	p.entry.SetText("")
+	p.entry.Enable()
+	p.guessBtn.Enable()
+	p.firstBtn.Enable()
+	p.lengthBtn.Enable()
+	p.optionsBtn.Enable()
+	p.updateHint()
+}
+
+// disable locks the panel while the answer is shown
+func (p *typedPanel) disable() {
+	p.entry.Disable()
+	p.guessBtn.Disable()
+	p.firstBtn.Disable()
+	p.lengthBtn.Disable()
+	p.optionsBtn.Disable()
+}
+
+func (p *typedPanel) submit(guess string) {
+	guess = strings.TrimSpace(guess)
+	if guess == "" || p.entry.Disabled() {
+		return
+	}
+	p.game.answer(utils.MatchCountry(guess, *p.game.currentCountry, utils.MatchCommon|utils.MatchOfficial))
+}
+
+// revealOptions falls back to the multiple choice buttons for half a point
+func (p *typedPanel) revealOptions() {
+	p.usedOptions = true
+	p.optionsBtn.Disable()
+	p.game.buttonGrid.Show()
+}
+
+func (p *typedPanel) updateHint() {
+	p.hintLabel.SetText(nameHint(p.game.currentCountry.Name.Common, p.showFirst, p.showLength))
+}
+
+// nameHint masks a country name, e.g. "F _ _ _ _ _ (6 letters)". Spaces and
+// punctuation are kept so the shape of multi-word names is visible.
+func nameHint(name string, showFirst, showLength bool) string {
+	first, _ := utf8.DecodeRuneInString(name)
+	if !showLength {
+		if showFirst {
+			return fmt.Sprintf(lang.X("game.flag.hint_starts_with", "Starts with %s"), string(first))
+		}
+		return ""
+	}
+
+	var masked []string
+	letters := 0
+	for i, r := range []rune(name) {
+		switch {
+		case !unicode.IsLetter(r):
+			masked = append(masked, string(r))
+		case i == 0 && showFirst:
+			masked = append(masked, string(r))
+			letters++
+		default:
+			masked = append(masked, "_")
+			letters++
+		}
+	}
+	return fmt.Sprintf(lang.X("game.flag.hint_letters", "%s (%d letters)"), strings.Join(masked, " "), letters)
+}
+
+// GetContainer returns the typed answer panel
+func (p *typedPanel) GetContainer() *fyne.Container {
+	return p.content
+}
@@ -3,12 +3,12 @@ package guessing
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 	"runtime"
 	"strings"
 
 	"flagged-it/internal/data"
 	"flagged-it/internal/data/models"
+	"flagged-it/internal/games/rounds"
 	"flagged-it/internal/ui/components"
 	"flagged-it/internal/utils"
 	"flagged-it/pkg/assets"
@@ -54,6 +54,8 @@ type Game struct {
 	bodyGrid       *fyne.Container
 	bodyScroll     *container.Scroll
 	guesses        []models.Country
+	plan           *rounds.Plan
+	played         int // games played from the current plan
 }
 
 func NewGame(backFunc func()) *Game {
@@ -250,7 +252,21 @@ func (g *Game) newGame() {
 		return
 	}
 
-	g.currentCountry = &g.countries[rand.Intn(len(g.countries))]
+	// Each new game takes the next planned country so none repeats until
+	// every country has been played
+	if g.plan == nil {
+		plan, err := rounds.New(g.countries, rounds.Config{})
+		if err != nil {
+			g.statusLabel.SetText(rounds.ErrorText(err))
+			return
+		}
+		g.plan = plan
+	}
+	if g.played >= g.plan.Len() {
+		g.plan.Extend()
+	}
+	g.currentCountry = &g.plan.Rounds[g.played].Answer
+	g.played++
 	g.guesses = []models.Country{}
 	g.bodyGrid.RemoveAll()
 	g.guessEntry.SetText("")
@@ -0,0 +1,88 @@
+package hangman
+
+import (
+	"sort"
+	"strings"
+
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/utils"
+
+	"fyne.io/fyne/v2/lang"
+)
+
+// WordSource lists the words a country contributes to a category. Any string
+// attribute of the country data can become a category this way.
+type WordSource func(country models.Country) []string
+
+// category is a kind of word to guess
+type category struct {
+	id     string // stored with scores
+	label  string
+	prompt string
+	words  WordSource
+}
+
+func categories() []category {
+	return []category{
+		{
+			id:     "names",
+			label:  lang.X("game.hangman.category_names", "Country names"),
+			prompt: lang.X("game.hangman.guess_country", "Guess the country name!"),
+			words: func(country models.Country) []string {
+				return []string{utils.TranslateCountry(country)}
+			},
+		},
+		{
+			id:     "capitals",
+			label:  lang.X("game.hangman.category_capitals", "Capitals"),
+			prompt: lang.X("game.hangman.guess_capital", "Guess the capital!"),
+			words: func(country models.Country) []string {
+				return country.Capital
+			},
+		},
+		{
+			id:     "currencies",
+			label:  lang.X("game.hangman.category_currencies", "Currencies"),
+			prompt: lang.X("game.hangman.guess_currency", "Guess the currency!"),
+			words: func(country models.Country) []string {
+				var names []string
+				for _, currency := range country.Currencies {
+					names = append(names, currency.Name)
+				}
+				sort.Strings(names)
+				return names
+			},
+		},
+		{
+			id:     "languages",
+			label:  lang.X("game.hangman.category_languages", "Languages"),
+			prompt: lang.X("game.hangman.guess_language", "Guess the language!"),
+			words: func(country models.Country) []string {
+				var names []string
+				for code, name := range country.Languages {
+					names = append(names, utils.TranslateLanguage(code, name))
+				}
+				sort.Strings(names)
+				return names
+			},
+		},
+	}
+}
+
+// wordsByCountry gives each word of the category to the first country that
+// has it, so shared words like "Euro" or "English" come up only once
+func wordsByCountry(countries []models.Country, source WordSource) map[string][]string {
+	owners := make(map[string]bool)
+	words := make(map[string][]string)
+	for _, country := range countries {
+		for _, w := range source(country) {
+			key := strings.ToUpper(strings.TrimSpace(w))
+			if key == "" || owners[key] {
+				continue
+			}
+			owners[key] = true
+			words[country.CCA3] = append(words[country.CCA3], w)
+		}
+	}
+	return words
+}
@@ -0,0 +1,25 @@
+package hangman
+
+import (
+	"testing"
+
+	"flagged-it/internal/data"
+	"flagged-it/internal/utils"
+)
+
+// Under LANG=C the locale is one CLDR has no names for; every category must
+// still come up with words instead of panicking
+func TestCategoriesUnknownLocale(t *testing.T) {
+	previous := utils.GetCurrentLocale()
+	defer utils.SetCurrentLocale(previous)
+
+	countries := data.LoadCountries()
+	for _, locale := range []string{"C", "C.UTF-8"} {
+		utils.SetCurrentLocale(locale)
+		for _, c := range categories() {
+			if words := wordsByCountry(countries, c.words); len(words) == 0 {
+				t.Errorf("category %q has no words in locale %q", c.id, locale)
+			}
+		}
+	}
+}
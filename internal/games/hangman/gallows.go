@@ -0,0 +1,143 @@
+package hangman
+
+import (
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+)
+
+const (
+	gallowsWidth  = 160
+	gallowsHeight = 200
+	strokeWidth   = 3
+	// drawDuration is how long a new part takes to draw itself
+	drawDuration = 300 * time.Millisecond
+)
+
+// gallowsPart is one stroke of the drawing, a line or the head's circle
+type gallowsPart struct {
+	from, to fyne.Position // for the head, the circle's bounding box
+	circle   bool
+	object   fyne.CanvasObject
+}
+
+// gallows draws the hangman stage by stage with canvas lines and a circle,
+// in the theme's foreground colour
+type gallows struct {
+	container *fyne.Container
+	parts     []*gallowsPart
+	shown     int
+	animation *fyne.Animation
+}
+
+func newGallows() *gallows {
+	p := func(x, y float32) fyne.Position { return fyne.NewPos(x, y) }
+	g := &gallows{parts: []*gallowsPart{
+		{from: p(10, 190), to: p(110, 190)},             // base
+		{from: p(30, 190), to: p(30, 10)},               // pole
+		{from: p(30, 10), to: p(110, 10)},               // beam
+		{from: p(110, 10), to: p(110, 40)},              // rope
+		{from: p(92, 40), to: p(128, 76), circle: true}, // head
+		{from: p(110, 76), to: p(110, 130)},             // body
+		{from: p(110, 90), to: p(85, 115)},              // left arm
+		{from: p(110, 90), to: p(135, 115)},             // right arm
+		{from: p(110, 130), to: p(88, 165)},             // left leg
+		{from: p(110, 130), to: p(132, 165)},            // right leg
+	}}
+
+	drawing := container.NewWithoutLayout()
+	for _, part := range g.parts {
+		if part.circle {
+			circle := canvas.NewCircle(color.Transparent)
+			circle.StrokeWidth = strokeWidth
+			part.object = circle
+		} else {
+			line := canvas.NewLine(color.Black)
+			line.StrokeWidth = strokeWidth
+			part.object = line
+		}
+		part.object.Hide()
+		drawing.Add(part.object)
+	}
+
+	// The drawing has no layout, so a spacer gives it its size
+	spacer := canvas.NewRectangle(color.Transparent)
+	spacer.SetMinSize(fyne.NewSize(gallowsWidth, gallowsHeight))
+	g.container = container.NewCenter(container.NewStack(spacer, drawing))
+	return g
+}
+
+// update shows as much of the drawing as wrongs out of maxWrongs call for,
+// drawing any new parts with a short animation. Once lost, it turns red.
+func (g *gallows) update(wrongs, maxWrongs int) {
+	stage := len(g.parts)
+	if maxWrongs > 0 && wrongs < maxWrongs {
+		stage = wrongs * len(g.parts) / maxWrongs
+	}
+
+	stroke := theme.Color(theme.ColorNameForeground)
+	if wrongs >= maxWrongs {
+		stroke = theme.Color(theme.ColorNameError)
+	}
+
+	if g.animation != nil {
+		g.animation.Stop()
+	}
+	for i, part := range g.parts {
+		setStroke(part, stroke)
+		switch {
+		case i < stage && i < g.shown:
+			// Parts added by an earlier guess may still be mid-animation
+			part.place(1)
+			part.object.Show()
+		case i < stage:
+			// New parts start empty and the animation draws them in
+			part.place(0)
+			part.object.Show()
+		default:
+			part.object.Hide()
+		}
+		part.object.Refresh()
+	}
+
+	if stage > g.shown {
+		added := g.parts[g.shown:stage]
+		g.animation = fyne.NewAnimation(drawDuration, func(progress float32) {
+			for _, part := range added {
+				part.place(progress)
+				part.object.Refresh()
+			}
+		})
+		g.animation.Curve = fyne.AnimationEaseOut
+		g.animation.Start()
+	}
+	g.shown = stage
+}
+
+// place draws the part progress of the way, 0 to 1. Lines grow from their
+// start, the head grows from its centre.
+func (p *gallowsPart) place(progress float32) {
+	if p.circle {
+		centre := fyne.NewPos((p.from.X+p.to.X)/2, (p.from.Y+p.to.Y)/2)
+		radius := (p.to.X - p.from.X) / 2 * progress
+		p.object.Move(fyne.NewPos(centre.X-radius, centre.Y-radius))
+		p.object.Resize(fyne.NewSize(radius*2, radius*2))
+		return
+	}
+	line := p.object.(*canvas.Line)
+	line.Position1 = p.from
+	line.Position2 = fyne.NewPos(p.from.X+(p.to.X-p.from.X)*progress, p.from.Y+(p.to.Y-p.from.Y)*progress)
+}
+
+func setStroke(p *gallowsPart, c color.Color) {
+	switch object := p.object.(type) {
+	case *canvas.Line:
+		object.StrokeColor = c
+	case *canvas.Circle:
+		object.StrokeColor = c
+	}
+}
@@ -8,20 +8,34 @@ import (
 
 	"flagged-it/internal/data"
 	"flagged-it/internal/data/models"
+	"flagged-it/internal/games/rounds"
 	"flagged-it/internal/ui/components"
+	"flagged-it/internal/utils"
+	"flagged-it/pkg/assets"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/lang"
 	"fyne.io/fyne/v2/widget"
 )
 
+const (
+	defaultRounds    = 5
+	defaultMaxWrongs = 6
+)
+
+var (
+	roundCounts  = []int{5, 10, 20}
+	livesOptions = []int{4, 6, 8}
+)
+
 type Game struct {
 	content        *fyne.Container
 	backFunc       func()
 	countries      []models.Country
-	currentWord    string
-	guessedWord    []rune
+	word           word
+	layout         layout
 	wrongGuesses   int
 	maxWrongs      int
 	guessedLetters map[rune]bool
@@ -35,18 +49,30 @@ type Game struct {
 	score        int
 	total        int
 	gameProgress *components.GameProgress
+	plan         *rounds.Plan
+	mainContent   *fyne.Container
+	selectionView *fyne.Container
+	gameView      *fyne.Container
+	category      category
+	words         map[string][]string // the category's words by CCA3 code
+	hintFlag      *canvas.Image
+	playing       bool
+	rounds        int
+	gallows       *gallows
+	startTime     time.Time
 }
 
 func NewGame(backFunc func()) *Game {
 	g := &Game{
 		backFunc:       backFunc,
-		maxWrongs:      6,
+		maxWrongs:      defaultMaxWrongs,
+		rounds:         defaultRounds,
 		guessedLetters: make(map[rune]bool),
 		letterButtons:  make(map[rune]*components.Button),
 	}
 	g.loadCountries()
+	g.category = categories()[0]
 	g.setupUI()
-	g.newGame()
 	return g
 }
 
@@ -57,6 +83,72 @@ func (g *Game) loadCountries() {
 func (g *Game) setupUI() {
 	topBar := components.NewTopBar(lang.X("game.hangman.title", "Hangman"), g.backFunc, g.Reset)
 
+	g.setupSelectionView()
+	g.setupGameView()
+
+	g.mainContent = container.NewMax(g.selectionView)
+
+	g.content = container.NewBorder(
+		topBar.GetContainer(), nil, nil, nil,
+		g.mainContent,
+	)
+}
+
+func (g *Game) setupSelectionView() {
+	titleLabel := widget.NewLabel(lang.X("game.hangman.select_category", "Select Category"))
+	titleLabel.TextStyle.Bold = true
+
+	descLabel := widget.NewLabel(lang.X("game.hangman.choose_category", "Guess words letter by letter. After a few misses you get a hint."))
+	descLabel.Wrapping = fyne.TextWrapWord
+
+	all := categories()
+	labels := make([]string, len(all))
+	for i, c := range all {
+		labels[i] = c.label
+	}
+	categorySelector := widget.NewRadioGroup(labels, func(selected string) {
+		for i, label := range labels {
+			if label == selected {
+				g.category = all[i]
+			}
+		}
+	})
+	categorySelector.Required = true
+	categorySelector.SetSelected(labels[0])
+
+	livesLabels := make([]string, len(livesOptions))
+	for i, lives := range livesOptions {
+		livesLabels[i] = fmt.Sprintf(lang.X("game.hangman.lives", "%d lives"), lives)
+	}
+	livesSelector := widget.NewRadioGroup(livesLabels, func(selected string) {
+		for i, label := range livesLabels {
+			if label == selected {
+				g.maxWrongs = livesOptions[i]
+			}
+		}
+	})
+	livesSelector.Horizontal = true
+	livesSelector.Required = true
+	livesSelector.SetSelected(fmt.Sprintf(lang.X("game.hangman.lives", "%d lives"), defaultMaxWrongs))
+
+	roundPicker := components.NewRoundCountPicker(roundCounts, func(count int) {
+		g.rounds = count
+	})
+
+	startBtn := components.NewButton(lang.X("game.higher_lower.start", "Start Game"), g.startGame)
+	startBtn.Importance = widget.HighImportance
+
+	g.selectionView = container.NewVBox(
+		titleLabel,
+		descLabel,
+		categorySelector,
+		livesSelector,
+		roundPicker,
+		startBtn,
+	)
+}
+
+func (g *Game) setupGameView() {
 	g.wordLabel = widget.NewLabel("")
 	g.wordLabel.TextStyle.Monospace = true
 
@@ -64,6 +156,7 @@ func (g *Game) setupUI() {
 	g.wrongLabel = widget.NewLabel("")
 	g.statusLabel = widget.NewLabel(lang.X("game.hangman.guess_country", "Guess the country name!"))
 
+	g.keyboard = container.NewVBox()
 	g.setupKeyboard()
 
 	// Game progress component
@@ -75,24 +168,45 @@ func (g *Game) setupUI() {
 
 	// Header section with natural spacing
 	headerSection := container.NewVBox(
-		topBar.GetContainer(),
 		g.gameProgress.GetContainer(),
 		g.statusLabel,
 	)
 
+	g.gallows = newGallows()
+
+	// The associated country's flag replaces the letter count as a hint
+	g.hintFlag = canvas.NewImageFromResource(nil)
+	g.hintFlag.FillMode = canvas.ImageFillContain
+	g.hintFlag.SetMinSize(fyne.NewSize(90, 60))
+	g.hintFlag.Hide()
+
 	// Game content
 	gameContent := container.NewVBox(
 		g.wordLabel,
 		g.hintLabel,
+		container.NewHBox(g.hintFlag),
+		g.gallows.container,
 		g.wrongLabel,
 		g.keyboard,
 	)
 
-	g.content = container.NewVBox(
+	g.gameView = container.NewVBox(
 		headerSection,
 		gameContent,
 	)
+}
+
+// startGame plans the rounds for the chosen category and shows the game
+func (g *Game) startGame() {
+	g.score = 0
+	g.total = 0
+	g.startTime = time.Now()
+	g.gameProgress.Reset()
 
+	g.mainContent.RemoveAll()
+	g.mainContent.Add(g.gameView)
+	g.mainContent.Refresh()
+	g.newGame()
 }
 
 func (g *Game) newGame() {
@@ -101,58 +215,81 @@ func (g *Game) newGame() {
 		return
 	}
 
-	if g.total >= 5 {
-		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.complete", "Game Complete! Final Score: %d/10 (%.0f%%)"), g.score, float64(g.score)/5*100))
+	if g.total == 0 {
+		words := wordsByCountry(g.countries, g.category.words)
+		plan, err := rounds.New(g.countries, rounds.Config{
+			Rounds:   g.rounds,
+			Eligible: func(country models.Country) bool { return len(words[country.CCA3]) > 0 },
+		})
+		if err != nil {
+			g.statusLabel.SetText(rounds.ErrorText(err))
+			return
+		}
+		g.plan = plan
+		g.words = words
+	}
+
+	if g.total >= g.plan.Len() {
+		percent := float64(g.score) / float64(g.plan.Len()) * 100
+		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.complete_rounds", "Game Complete! Final Score: %d/%d (%.0f%%)"), g.score, g.plan.Len(), percent))
+		utils.SaveScore(utils.ScoreEntry{
+			GameMode: "hangman",
+			Score:    g.score,
+			Total:    g.plan.Len(),
+			Percent:  percent,
+			Duration: int(time.Since(g.startTime).Seconds()),
+			Variant:  g.variant(),
+		})
 		for _, btn := range g.letterButtons {
 			btn.Disable()
 		}
+		g.playing = false
 		return
 	}
 
-	rand.Seed(time.Now().UnixNano())
-	country := g.countries[rand.Intn(len(g.countries))]
-	g.currentWord = strings.ToUpper(country.Name.Common)
-	g.guessedWord = make([]rune, len(g.currentWord))
+	// The language may have changed since the keyboard was built
+	if g.layout.locale != utils.GetCurrentLocale() {
+		g.setupKeyboard()
+	}
+
+	country := g.plan.Rounds[g.total].Answer
+	candidates := g.words[country.CCA3]
+	g.word = newWord(candidates[rand.Intn(len(candidates))], g.layout)
 	g.wrongGuesses = 0
 	g.guessedLetters = make(map[rune]bool)
+	g.playing = true
 
-	for i, char := range g.currentWord {
-		if char == ' ' {
-			g.guessedWord[i] = ' '
-		} else {
-			g.guessedWord[i] = '_'
-		}
+	g.hintFlag.Hide()
+	if flagResource, err := assets.LoadFlagResource(country.CCA2); err == nil {
+		g.hintFlag.Resource = flagResource
+		g.hintFlag.Refresh()
 	}
 
 	g.updateDisplay()
-	g.statusLabel.SetText(lang.X("game.hangman.guess_country", "Guess the country name!"))
+	g.statusLabel.SetText(g.category.prompt)
 	for _, btn := range g.letterButtons {
 		btn.Enable()
 	}
 }
 
+// setupKeyboard lays out the letter keys for the current language
 func (g *Game) setupKeyboard() {
-	rows := []string{"QWERTYUIOP", "ASDFGHJKL", "ZXCVBNM"}
+	g.layout = layoutFor(utils.GetCurrentLocale())
+	g.letterButtons = make(map[rune]*components.Button)
+	g.keyboard.RemoveAll()
 
-	keyboardRows := make([]*fyne.Container, len(rows))
-	for i, row := range rows {
-		buttons := make([]fyne.CanvasObject, len(row))
-		for j, letter := range row {
+	for _, row := range g.layout.rows {
+		var buttons []fyne.CanvasObject
+		for _, letter := range row {
 			letter := letter
 			btn := components.NewButton(string(letter), func() {
 				g.makeGuess(letter)
 			})
 			g.letterButtons[letter] = btn
-			buttons[j] = btn
+			buttons = append(buttons, btn)
 		}
-		keyboardRows[i] = container.NewHBox(buttons...)
+		g.keyboard.Add(container.NewCenter(container.NewHBox(buttons...)))
 	}
-
-	g.keyboard = container.NewVBox(
-		container.NewCenter(keyboardRows[0]),
-		container.NewCenter(keyboardRows[1]),
-		container.NewCenter(keyboardRows[2]),
-	)
 }
 
 func (g *Game) makeGuess(letter rune) {
@@ -163,16 +300,8 @@ func (g *Game) makeGuess(letter rune) {
 
 	g.guessedLetters[letter] = true
 	g.letterButtons[letter].Disable()
-	found := false
-
-	for i, char := range g.currentWord {
-		if char == letter {
-			g.guessedWord[i] = letter
-			found = true
-		}
-	}
 
-	if !found {
+	if !g.word.guess(letter, g.layout) {
 		g.wrongGuesses++
 	}
 
@@ -181,89 +310,108 @@ func (g *Game) makeGuess(letter rune) {
 }
 
 func (g *Game) updateDisplay() {
-	var displayWord strings.Builder
-	for i, char := range g.guessedWord {
-		if char == ' ' {
-			displayWord.WriteString("   ")
-		} else {
-			displayWord.WriteRune(char)
-			if i < len(g.guessedWord)-1 && g.guessedWord[i+1] != ' ' {
-				displayWord.WriteString(" ")
-			}
+	letterCount, wordCount := g.word.counts()
+
+	g.wordLabel.SetText(g.word.display())
+	if g.wrongGuesses >= g.hintAfter() {
+		g.hintLabel.SetText(lang.X("game.hangman.hint_flag", "Hint: it belongs to this country"))
+		g.hintFlag.Show()
+	} else {
+		wordText := lang.X("game.hangman.word", "word")
+		if wordCount != 1 {
+			wordText = lang.X("game.hangman.words", "words")
 		}
+		g.hintLabel.SetText(fmt.Sprintf(lang.X("game.hangman.letters_words", "%d letters, %d %s"), letterCount, wordCount, wordText))
 	}
+	g.wrongLabel.SetText(fmt.Sprintf(lang.X("game.hangman.wrong_guesses", "Wrong guesses: %d/%d"), g.wrongGuesses, g.maxWrongs))
+	g.gallows.update(g.wrongGuesses, g.maxWrongs)
+}
 
-	letterCount := 0
-	wordCount := 1
-	for _, char := range g.currentWord {
-		if char == ' ' {
-			wordCount++
-		} else {
-			letterCount++
-		}
+// variant describes the settings for the scoreboard, leaving out defaults
+func (g *Game) variant() string {
+	var parts []string
+	if g.category.id != "names" {
+		parts = append(parts, g.category.id)
 	}
-
-	g.wordLabel.SetText(displayWord.String())
-	wordText := lang.X("game.hangman.word", "word")
-	if wordCount != 1 {
-		wordText = lang.X("game.hangman.words", "words")
+	if g.maxWrongs != defaultMaxWrongs {
+		parts = append(parts, fmt.Sprintf("%d lives", g.maxWrongs))
 	}
-	g.hintLabel.SetText(fmt.Sprintf(lang.X("game.hangman.letters_words", "%d letters, %d %s"), letterCount, wordCount, wordText))
-	g.wrongLabel.SetText(fmt.Sprintf(lang.X("game.hangman.wrong_guesses", "Wrong guesses: %d/%d"), g.wrongGuesses, g.maxWrongs))
+	if g.rounds != defaultRounds {
+		parts = append(parts, fmt.Sprintf("%d rounds", g.rounds))
+	}
+	return strings.Join(parts, " ")
+}
+
+// hintAfter is how many wrong guesses it takes to get the flag hint
+func (g *Game) hintAfter() int {
+	return g.maxWrongs / 2
 }
 
 func (g *Game) checkGameEnd() {
 	if g.wrongGuesses >= g.maxWrongs {
-		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.hangman.game_over", "Game Over! The word was: %s"), g.currentWord))
+		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.hangman.game_over", "Game Over! The word was: %s"), g.word))
 		for _, btn := range g.letterButtons {
 			btn.Disable()
 		}
 		g.total++
-		g.gameProgress.UpdateProgress(g.total, 5, g.score)
-		time.AfterFunc(1500*time.Millisecond, func() {
-			fyne.Do(func() {
-				g.newGame()
-			})
-		})
+		g.gameProgress.UpdateProgress(g.total, g.plan.Len(), g.score)
+		g.nextRoundLater()
 		return
 	}
 
-	if !strings.Contains(string(g.guessedWord), "_") {
+	if g.word.solved() {
 		g.statusLabel.SetText(lang.X("game.hangman.congratulations", "Congratulations! You won!"))
 		for _, btn := range g.letterButtons {
 			btn.Disable()
 		}
 		g.total++
 		g.score++
-		g.gameProgress.UpdateProgress(g.total, 5, g.score)
-		time.AfterFunc(1500*time.Millisecond, func() {
-			fyne.Do(func() {
+		g.gameProgress.UpdateProgress(g.total, g.plan.Len(), g.score)
+		g.nextRoundLater()
+	}
+}
+
+// nextRoundLater moves on after a pause, unless the player has left or
+// restarted the game meanwhile
+func (g *Game) nextRoundLater() {
+	plan := g.plan
+	time.AfterFunc(1500*time.Millisecond, func() {
+		fyne.Do(func() {
+			if g.plan == plan && g.mainContent.Objects[0] == g.gameView {
 				g.newGame()
-			})
+			}
 		})
-	}
+	})
 }
 
 func (g *Game) GetContent() *fyne.Container {
 	return g.content
 }
 
+func (g *Game) showSelection() {
+	g.playing = false
+	g.mainContent.RemoveAll()
+	g.mainContent.Add(g.selectionView)
+	g.mainContent.Refresh()
+}
+
 func (g *Game) Start() {
-	g.newGame()
+	g.showSelection()
 }
 
 func (g *Game) Reset() {
-	g.score = 0
-	g.total = 0
-	g.gameProgress.Reset()
-	g.newGame()
+	g.showSelection()
 }
 
-func (g *Game) TypedKey(key *fyne.KeyEvent) {
-	if len(string(key.Name)) == 1 {
-		letter := rune(strings.ToUpper(string(key.Name))[0])
-		if letter >= 'A' && letter <= 'Z' {
-			g.makeGuess(letter)
+// TypedRune guesses the key for a typed letter; accented letters without a
+// key of their own count as their base letter
+func (g *Game) TypedRune(r rune) {
+	if !g.playing {
+		return
+	}
+	for _, letter := range g.layout.upper(string(r)) {
+		if key := g.layout.keyFor(letter); key != 0 && g.letterButtons[key] != nil && !g.letterButtons[key].Disabled() {
+			g.makeGuess(key)
 		}
 	}
 }
@@ -0,0 +1,102 @@
+package hangman
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// layout is an on-screen keyboard for one locale. Letters it has a key for
+// are guessed as they are; any other accented letter is guessed with the
+// key of its base letter.
+type layout struct {
+	locale string
+	rows   []string
+	keys   map[rune]bool
+}
+
+var (
+	qwerty = []string{"QWERTYUIOP", "ASDFGHJKL", "ZXCVBNM"}
+	qwertz = []string{"QWERTZUIOP", "ASDFGHJKL", "YXCVBNM"}
+	azerty = []string{"AZERTYUIOP", "QSDFGHJKLM", "WXCVBN"}
+)
+
+// layouts maps a language to its letter rows; extra letters get a row of
+// their own. Languages not listed use plain QWERTY.
+var layouts = map[string][]string{
+	"cs": append(qwertz, "ČĎĚŇŘŠŤŮŽ"),
+	"da": append(qwerty, "ÆØÅ"),
+	"de": append(qwertz, "ÄÖÜ"),
+	"es": append(qwerty, "Ñ"),
+	"fi": append(qwerty, "ÅÄÖ"),
+	"fr": azerty,
+	"hr": append(qwertz, "ČĆĐŠŽ"),
+	"hu": append(qwertz, "ÖÜŐŰ"),
+	"nb": append(qwerty, "ÆØÅ"),
+	"pl": append(qwerty, "ĄĆĘŁŃÓŚŹŻ"),
+	"pt": append(qwerty, "Ç"),
+	"ro": append(qwerty, "ĂÂÎȘȚ"),
+	"sk": append(qwertz, "ČĎĽŇŠŤŽ"),
+	"sv": append(qwerty, "ÅÄÖ"),
+	"tr": append(qwerty, "ÇĞİÖŞÜ"),
+}
+
+// baseLetters folds letters that don't decompose into a base letter plus
+// accents
+var baseLetters = map[rune]rune{
+	'Ø': 'O',
+	'Ł': 'L',
+	'Đ': 'D',
+	'Ð': 'D',
+	'ß': 'S',
+	'ẞ': 'S',
+	'ı': 'I',
+}
+
+func layoutFor(locale string) layout {
+	base, _ := language.Make(locale).Base()
+	rows, ok := layouts[base.String()]
+	if !ok {
+		rows = qwerty
+	}
+
+	l := layout{locale: locale, rows: rows, keys: make(map[rune]bool)}
+	for _, row := range rows {
+		for _, key := range row {
+			l.keys[key] = true
+		}
+	}
+	return l
+}
+
+// upper uppercases a name the way the locale does, so Turkish "i" becomes "İ"
+func (l layout) upper(s string) string {
+	if base, _ := language.Make(l.locale).Base(); base.String() == "tr" || base.String() == "az" {
+		return strings.ToUpperSpecial(unicode.TurkishCase, s)
+	}
+	return strings.ToUpper(s)
+}
+
+// keyFor returns the key that guesses an uppercase letter, or 0 if no key can
+func (l layout) keyFor(r rune) rune {
+	if l.keys[r] {
+		return r
+	}
+	if folded := fold(r); l.keys[folded] {
+		return folded
+	}
+	return 0
+}
+
+// fold strips accents from a letter, e.g. Ô becomes O
+func fold(r rune) rune {
+	if base, ok := baseLetters[r]; ok {
+		return base
+	}
+	for _, decomposed := range norm.NFD.String(string(r)) {
+		return unicode.ToUpper(decomposed)
+	}
+	return r
+}
@@ -0,0 +1,84 @@
+package hangman
+
+import (
+	"strings"
+	"unicode"
+)
+
+// word is the name being guessed, kept as runes so accented and non-Latin
+// letters take one slot each
+type word struct {
+	runes    []rune
+	revealed []bool
+}
+
+// newWord hides every letter that a key on the layout can guess. Spaces,
+// hyphens, apostrophes and anything else without a key are shown from the
+// start so every name can be won.
+func newWord(name string, keys layout) word {
+	w := word{runes: []rune(keys.upper(name))}
+	w.revealed = make([]bool, len(w.runes))
+	for i, r := range w.runes {
+		w.revealed[i] = !unicode.IsLetter(r) || keys.keyFor(r) == 0
+	}
+	return w
+}
+
+// guess reveals every letter the key stands for and reports whether there was one
+func (w *word) guess(key rune, keys layout) bool {
+	found := false
+	for i, r := range w.runes {
+		if !w.revealed[i] && keys.keyFor(r) == key {
+			w.revealed[i] = true
+			found = true
+		}
+	}
+	return found
+}
+
+func (w word) solved() bool {
+	for _, revealed := range w.revealed {
+		if !revealed {
+			return false
+		}
+	}
+	return true
+}
+
+// display spaces the letters out and widens the gaps between words
+func (w word) display() string {
+	var b strings.Builder
+	for i, r := range w.runes {
+		if r == ' ' {
+			b.WriteString("   ")
+			continue
+		}
+		if w.revealed[i] {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+		if i < len(w.runes)-1 && w.runes[i+1] != ' ' {
+			b.WriteString(" ")
+		}
+	}
+	return b.String()
+}
+
+// counts returns the number of letters and words for the hint
+func (w word) counts() (letters, words int) {
+	words = 1
+	for _, r := range w.runes {
+		switch {
+		case r == ' ':
+			words++
+		case unicode.IsLetter(r):
+			letters++
+		}
+	}
+	return letters, words
+}
+
+func (w word) String() string {
+	return string(w.runes)
+}
@@ -0,0 +1,47 @@
+package higher_lower
+
+import (
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/utils"
+	"flagged-it/pkg/assets"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// countryCard shows one side of the comparison: the flag, the name and the
+// metric value, which stays hidden until the guess is in
+type countryCard struct {
+	container *fyne.Container
+	flag      *canvas.Image
+	name      *widget.Label
+	value     *widget.Label
+}
+
+func newCountryCard() *countryCard {
+	c := &countryCard{
+		flag:  canvas.NewImageFromResource(nil),
+		name:  widget.NewLabel(""),
+		value: widget.NewLabel(""),
+	}
+	c.flag.FillMode = canvas.ImageFillContain
+	c.flag.SetMinSize(fyne.NewSize(120, 80))
+	c.name.Alignment = fyne.TextAlignCenter
+	c.name.TextStyle.Bold = true
+	c.value.Alignment = fyne.TextAlignCenter
+
+	c.container = container.NewVBox(c.flag, c.name, c.value)
+	return c
+}
+
+func (c *countryCard) set(country models.Country, value string) {
+	c.flag.Resource = nil
+	if flagResource, err := assets.LoadFlagResource(country.CCA2); err == nil {
+		c.flag.Resource = flagResource
+	}
+	c.flag.Refresh()
+	c.name.SetText(utils.TranslateCountry(country))
+	c.value.SetText(value)
+}
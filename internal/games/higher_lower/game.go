@@ -2,9 +2,12 @@ package higher_lower
 
 import (
 	"flagged-it/internal/data"
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/games/rounds"
 	"flagged-it/internal/ui/components"
+	"flagged-it/internal/utils"
 	"fmt"
-	"math/rand"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -12,41 +15,101 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
-type Game struct {
-	content  *fyne.Container
-	backFunc func()
+// revealDuration is how long the hidden value takes to count up to its
+// real value once the player has guessed
+const revealDuration = 800 * time.Millisecond
 
-	firstCountry  int
-	secondCountry int
-	score         int
+type Game struct {
+	content       *fyne.Container
+	backFunc      func()
+	mainContent   *fyne.Container
+	selectionView *fyne.Container
+	gameView      *fyne.Container
+	countries     []models.Country
+	metric        utils.CountryMetric
+
+	firstCountry  models.Country
+	secondCountry models.Country
+	score         int // the current streak
 	highestStreak int
-
-	countryOneNameLabel *widget.Label
-	countryTwoNameLabel *widget.Label
-	countryOnePopLabel  *widget.Label
-	countryTwoPopLabel  *widget.Label
-	nextBtn             *components.Button
-	higherBtn           *components.Button
-	lowerBtn            *components.Button
-	currentStreakLabel  *widget.Label
-	highestStreakLabel  *widget.Label
+	plan          *rounds.Plan
+	next          int // index of the next planned country
+	reveal        *fyne.Animation
+
+	promptLabel        *widget.Label
+	statusLabel        *widget.Label
+	firstCard          *countryCard
+	secondCard         *countryCard
+	nextBtn            *components.Button
+	higherBtn          *components.Button
+	lowerBtn           *components.Button
+	currentStreakLabel *widget.Label
+	highestStreakLabel *widget.Label
 }
 
 func NewGame(backFunc func()) *Game {
-	g := &Game{backFunc: backFunc}
+	g := &Game{
+		backFunc:  backFunc,
+		countries: data.LoadCountries(),
+		metric:    utils.MetricPopulation,
+	}
 	g.setupUI()
 	return g
 }
 
 func (g *Game) setupUI() {
-	topBar := components.NewTopBar(lang.X("game.higher_lower.title", "Higher or Lower Game"), g.backFunc, g.Reset)
+	// A streak still running when the player leaves is saved as it stands
+	back := func() {
+		g.endStreak()
+		g.backFunc()
+	}
+	topBar := components.NewTopBar(lang.X("game.higher_lower.title", "Higher or Lower Game"), back, g.Reset)
+
+	g.setupSelectionView()
+	g.setupGameView()
+
+	g.mainContent = container.NewMax(g.selectionView)
+
+	g.content = container.NewBorder(
+		topBar.GetContainer(), nil, nil, nil,
+		g.mainContent,
+	)
+}
+
+func (g *Game) setupSelectionView() {
+	titleLabel := widget.NewLabel(lang.X("game.ranking.select_metric", "Select Metric"))
+	titleLabel.TextStyle.Bold = true
+
+	descLabel := widget.NewLabel(lang.X("game.higher_lower.choose_metric", "Guess whether the next country is higher or lower. How long can your streak get?"))
+	descLabel.Wrapping = fyne.TextWrapWord
 
-	gameDescription := widget.NewLabel(lang.X("game.higher_lower.description", "Try to guess which country has a higher population!"))
+	columns := 2
+	if utils.IsMobile() {
+		columns = 1
+	}
+	buttonGrid := container.NewGridWithColumns(columns)
+	for _, metric := range utils.CountryMetrics {
+		metric := metric
+		buttonGrid.Add(components.NewButton(metric.Label(), func() {
+			g.startGame(metric)
+		}))
+	}
+
+	g.selectionView = container.NewVBox(
+		titleLabel,
+		descLabel,
+		buttonGrid,
+	)
+}
 
-	g.countryOneNameLabel = widget.NewLabel("")
-	g.countryOnePopLabel = widget.NewLabel("")
-	g.countryTwoNameLabel = widget.NewLabel("")
-	g.countryTwoPopLabel = widget.NewLabel("")
+func (g *Game) setupGameView() {
+	g.promptLabel = widget.NewLabel("")
+	g.promptLabel.Wrapping = fyne.TextWrapWord
+	g.statusLabel = widget.NewLabel("")
+	g.statusLabel.Alignment = fyne.TextAlignCenter
+
+	g.firstCard = newCountryCard()
+	g.secondCard = newCountryCard()
 
 	// Current streak label
 	g.currentStreakLabel = widget.NewLabel("0")
@@ -56,8 +119,6 @@ func (g *Game) setupUI() {
 	g.highestStreakLabel = widget.NewLabel("0")
 	g.highestStreakLabel.Alignment = fyne.TextAlignTrailing
 
-	var startBtn *components.Button
-
 	g.higherBtn = components.NewButton(lang.X("game.higher_lower.higher", "Higher"), func() {
 		g.makeGuess(true)
 	})
@@ -67,18 +128,8 @@ func (g *Game) setupUI() {
 	g.nextBtn = components.NewButton(lang.X("game.higher_lower.next_round", "Next Round"), func() {
 		g.nextRound()
 	})
-
-	g.higherBtn.Hide()
-	g.lowerBtn.Hide()
 	g.nextBtn.Hide()
 
-	startBtn = components.NewButton(lang.X("game.higher_lower.start", "Start Game"), func() {
-		g.Start()
-		startBtn.Hide()
-		g.higherBtn.Show()
-		g.lowerBtn.Show()
-	})
-
 	// Create responsive button grid
 	buttonGrid := container.NewGridWithColumns(2, g.higherBtn, g.lowerBtn)
 
@@ -91,31 +142,118 @@ func (g *Game) setupUI() {
 
 	// Header section with natural spacing
 	headerSection := container.NewVBox(
-		topBar.GetContainer(),
-		gameDescription,
+		g.promptLabel,
 		currentStreakContainer,
 		highestStreakContainer,
 	)
 
 	// Game content
 	gameContent := container.NewVBox(
-		startBtn,
-		container.NewCenter(g.countryOneNameLabel),
-		container.NewCenter(g.countryOnePopLabel),
-		container.NewCenter(g.countryTwoNameLabel),
-		container.NewCenter(g.countryTwoPopLabel),
+		container.NewGridWithColumns(2, g.firstCard.container, g.secondCard.container),
+		g.statusLabel,
 		buttonGrid,
 		g.nextBtn,
 	)
 
-	g.content = container.NewVBox(
+	g.gameView = container.NewVBox(
 		headerSection,
 		gameContent,
 	)
 }
 
+// startGame starts a new streak comparing countries by metric
+func (g *Game) startGame(metric utils.CountryMetric) {
+	g.metric = metric
+	g.score = 0
+	g.highestStreak = bestStreak(metric)
+	g.currentStreakLabel.SetText("0")
+	g.highestStreakLabel.SetText(fmt.Sprintf("%d", g.highestStreak))
+	g.promptLabel.SetText(fmt.Sprintf(lang.X("game.higher_lower.prompt", "%s: is the second country higher or lower?"), metric.Label()))
+
+	g.mainContent.RemoveAll()
+	g.mainContent.Add(g.gameView)
+	g.mainContent.Refresh()
+
+	// Every round compares two countries that both have a value
+	plan, err := rounds.New(g.countries, rounds.Config{
+		Eligible: func(country models.Country) bool {
+			_, ok := metric.Value(country)
+			return ok
+		},
+	})
+	if err == nil && plan.Len() < 2 {
+		err = rounds.ErrPoolTooSmall
+	}
+	if err != nil {
+		g.plan = nil
+		g.statusLabel.SetText(rounds.ErrorText(err))
+		g.higherBtn.Hide()
+		g.lowerBtn.Hide()
+		g.nextBtn.Hide()
+		return
+	}
+	g.plan = plan
+	g.next = 2
+
+	g.firstCountry = plan.Rounds[0].Answer
+	g.secondCountry = plan.Rounds[1].Answer
+	g.showRound()
+}
+
+// showRound shows the first country's value and hides the second's
+func (g *Game) showRound() {
+	g.firstCard.set(g.firstCountry, g.format(g.firstCountry))
+	g.secondCard.set(g.secondCountry, "?")
+	g.statusLabel.SetText("")
+	g.nextBtn.Hide()
+	g.higherBtn.Show()
+	g.lowerBtn.Show()
+	g.higherBtn.Enable()
+	g.lowerBtn.Enable()
+}
+
+func (g *Game) format(country models.Country) string {
+	value, _ := g.metric.Value(country)
+	return g.metric.FormatCompact(value)
+}
+
 func (g *Game) makeGuess(isHigher bool) {
-	correct := (isHigher && g.secondCountry > g.firstCountry) || (!isHigher && g.secondCountry < g.firstCountry)
+	g.higherBtn.Disable()
+	g.lowerBtn.Disable()
+
+	first, _ := g.metric.Value(g.firstCountry)
+	second, _ := g.metric.Value(g.secondCountry)
+	// Only equal values tie; close ones that read the same on the cards are
+	// told apart on reveal
+	tie := first == second
+	correct := tie || (isHigher && second > first) || (!isHigher && second < first)
+
+	// Count the hidden value up from the first country's, then give the verdict
+	if g.reveal != nil {
+		g.reveal.Stop()
+	}
+	g.reveal = fyne.NewAnimation(revealDuration, func(progress float32) {
+		g.secondCard.value.SetText(g.metric.FormatCompact(first + (second-first)*float64(progress)))
+		if progress >= 1 {
+			g.firstCard.value.SetText(g.metric.Format(first))
+			g.secondCard.value.SetText(g.metric.Format(second))
+			g.showResult(correct, tie)
+		}
+	})
+	g.reveal.Curve = fyne.AnimationEaseOut
+	g.reveal.Start()
+}
+
+func (g *Game) showResult(correct, tie bool) {
+	switch {
+	case tie:
+		g.statusLabel.SetText(lang.X("game.higher_lower.tie", "It's a tie! Both answers count."))
+	case correct:
+		g.statusLabel.SetText(lang.X("game.higher_lower.correct", "Correct!"))
+	default:
+		g.statusLabel.SetText(lang.X("game.higher_lower.wrong", "Wrong! The streak starts over."))
+	}
+
 	if correct {
 		g.score++
 		// Update highest streak if current is higher
@@ -124,34 +262,65 @@ func (g *Game) makeGuess(isHigher bool) {
 			g.highestStreakLabel.SetText(fmt.Sprintf("%d", g.highestStreak))
 		}
 	} else {
+		g.saveStreak(g.score + 1)
 		g.score = 0
 	}
 	g.currentStreakLabel.SetText(fmt.Sprintf("%d", g.score))
-	g.countryTwoPopLabel.SetText(fmt.Sprintf(lang.X("game.higher_lower.population", "Population: %d"), g.secondCountry))
 	g.higherBtn.Hide()
 	g.lowerBtn.Hide()
 	g.nextBtn.Show()
 }
 
 func (g *Game) nextRound() {
-	countries := data.LoadCountries()
-	newCountry := countries[rand.Intn(len(countries))]
+	// Streaks can outlast the pool; the planner never repeats the last country
+	if g.next >= g.plan.Len() {
+		g.plan.Extend()
+	}
+	g.firstCountry = g.secondCountry
+	g.secondCountry = g.plan.Rounds[g.next].Answer
+	g.next++
+	g.showRound()
+}
 
-	for newCountry.Name.Common == g.countryTwoNameLabel.Text {
-		newCountry = countries[rand.Intn(len(countries))]
+// endStreak saves a streak the player walks away from
+func (g *Game) endStreak() {
+	if g.reveal != nil {
+		g.reveal.Stop()
 	}
+	g.saveStreak(g.score)
+	g.score = 0
+}
 
-	g.firstCountry = g.secondCountry
-	g.countryOneNameLabel.SetText(g.countryTwoNameLabel.Text)
-	g.countryOnePopLabel.SetText(fmt.Sprintf(lang.X("game.higher_lower.population", "Population: %d"), g.firstCountry))
+// saveStreak records the streak for the current metric out of the answers
+// given in it, so a run ended by a wrong answer counts that answer too
+func (g *Game) saveStreak(answers int) {
+	if g.score == 0 {
+		return
+	}
+	utils.SaveScore(utils.ScoreEntry{
+		GameMode: "higher_lower",
+		Score:    g.score,
+		Total:    answers,
+		Percent:  float64(g.score) / float64(answers) * 100,
+		Variant:  string(g.metric),
+	})
+}
 
-	g.secondCountry = newCountry.Population
-	g.countryTwoNameLabel.SetText(newCountry.Name.Common)
-	g.countryTwoPopLabel.SetText(lang.X("game.higher_lower.population_unknown", "Population: ?"))
+// bestStreak returns the highest streak saved for a metric
+func bestStreak(metric utils.CountryMetric) int {
+	best := 0
+	for _, score := range utils.GetScoreboard() {
+		if score.GameMode == "higher_lower" && score.Variant == string(metric) && score.Score > best {
+			best = score.Score
+		}
+	}
+	return best
+}
 
-	g.nextBtn.Hide()
-	g.higherBtn.Show()
-	g.lowerBtn.Show()
+func (g *Game) showSelection() {
+	g.mainContent.RemoveAll()
+	g.mainContent.Add(g.selectionView)
+	g.mainContent.Refresh()
 }
 
 func (g *Game) GetContent() *fyne.Container {
@@ -159,28 +328,10 @@ func (g *Game) GetContent() *fyne.Container {
 }
 
 func (g *Game) Start() {
-	countries := data.LoadCountries()
-
-	firstRandomCountry := countries[rand.Intn(len(countries))]
-	secondRandomCountry := countries[rand.Intn(len(countries))]
-
-	for firstRandomCountry.Name.Common == secondRandomCountry.Name.Common {
-		secondRandomCountry = countries[rand.Intn(len(countries))]
-	}
-
-	g.firstCountry = firstRandomCountry.Population
-	g.secondCountry = secondRandomCountry.Population
-
-	g.countryOneNameLabel.SetText(firstRandomCountry.Name.Common)
-	g.countryOnePopLabel.SetText(fmt.Sprintf(lang.X("game.higher_lower.population", "Population: %d"), firstRandomCountry.Population))
-	g.countryTwoNameLabel.SetText(secondRandomCountry.Name.Common)
-	g.countryTwoPopLabel.SetText(lang.X("game.higher_lower.population_unknown", "Population: ?"))
+	g.showSelection()
 }
 
 func (g *Game) Reset() {
-	g.score = 0
-	g.highestStreak = 0
-	g.currentStreakLabel.SetText("0")
-	g.highestStreakLabel.SetText("0")
-	g.Start()
+	g.endStreak()
+	g.showSelection()
 }
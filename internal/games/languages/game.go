@@ -0,0 +1,517 @@
+package languages
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"flagged-it/internal/data"
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/games/rounds"
+	"flagged-it/internal/ui/components"
+	"flagged-it/internal/utils"
+	"flagged-it/pkg/assets"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	totalRounds = 10
+	// listEvery makes every n-th round a "name all countries" round
+	listEvery = 5
+	// minListCountries and maxListCountries bound the languages used for list rounds
+	minListCountries = 3
+	maxListCountries = 31
+)
+
+type questionType int
+
+const (
+	// countryToLanguage asks which language is official in a country
+	countryToLanguage questionType = iota
+	// languageToCountry asks which of the listed countries has a language as official
+	languageToCountry
+	// listCountries asks the player to name every country where a language is official
+	listCountries
+)
+
+// option is a single answer button; code is a language code or a CCA2
+type option struct {
+	label string
+	code  string
+}
+
+type Game struct {
+	content         *fyne.Container
+	backFunc        func()
+	mainContent     *fyne.Container
+	choiceView      *fyne.Container
+	listView        *fyne.Container
+	countries       []models.Country
+	languageNames   map[string]string          // language code -> English name
+	languageUsers   map[string]map[string]bool // language code -> set of CCA2
+	currentCountry  *models.Country
+	currentLanguage string
+	question        questionType
+	plan            *rounds.Plan
+	options         []option
+	flagImage       *canvas.Image
+	statusLabel     *widget.Label
+	buttons         []*components.ColoredButton
+	buttonGrid      *fyne.Container
+	listCountries   []models.Country
+	listGuessed     map[string]bool
+	listRevealed    bool
+	guessEntry      *widget.Entry
+	countryList     *components.AnswerList
+	listProgress    *widget.Label
+	doneBtn         *components.Button
+	nextBtn         *components.Button
+	score           int
+	total           int
+	startTime       time.Time
+	gameProgress    *components.GameProgress
+}
+
+func NewGame(backFunc func()) *Game {
+	g := &Game{
+		backFunc: backFunc,
+	}
+	g.loadCountries()
+	g.setupUI()
+	g.newGame()
+	return g
+}
+
+// loadCountries keeps only countries with official languages and indexes
+// which countries share each language
+func (g *Game) loadCountries() {
+	g.languageNames = make(map[string]string)
+	g.languageUsers = make(map[string]map[string]bool)
+
+	for _, country := range data.LoadCountries() {
+		if len(country.Languages) == 0 {
+			continue
+		}
+		g.countries = append(g.countries, country)
+		for code, name := range country.Languages {
+			g.languageNames[code] = name
+			if g.languageUsers[code] == nil {
+				g.languageUsers[code] = make(map[string]bool)
+			}
+			g.languageUsers[code][country.CCA2] = true
+		}
+	}
+}
+
+func (g *Game) setupUI() {
+	topBar := components.NewTopBar(lang.X("game.languages.title", "Official Languages"), g.backFunc, g.Reset)
+
+	g.statusLabel = widget.NewLabel("")
+	g.statusLabel.Wrapping = fyne.TextWrapWord
+
+	g.gameProgress = components.NewGameProgress(components.GameProgressConfig{
+		ShowRounds:      true,
+		ShowPercentage:  true,
+		ShowProgressBar: true,
+	})
+
+	g.setupChoiceView()
+	g.setupListView()
+
+	g.mainContent = container.NewMax(g.choiceView)
+
+	headerSection := container.NewVBox(
+		topBar.GetContainer(),
+		g.gameProgress.GetContainer(),
+		g.statusLabel,
+	)
+
+	g.content = container.NewBorder(
+		headerSection, nil, nil, nil,
+		g.mainContent,
+	)
+}
+
+func (g *Game) setupChoiceView() {
+	g.flagImage = canvas.NewImageFromResource(nil)
+	g.flagImage.FillMode = canvas.ImageFillContain
+	if utils.IsMobile() {
+		g.flagImage.SetMinSize(fyne.NewSize(240, 144))
+	} else {
+		g.flagImage.SetMinSize(fyne.NewSize(400, 240))
+	}
+
+	columns := 2
+	if utils.IsMobile() {
+		columns = 1
+	}
+	g.buttonGrid = container.NewGridWithColumns(columns)
+
+	g.choiceView = container.NewBorder(
+		nil,
+		container.NewVBox(g.buttonGrid),
+		nil, nil,
+		container.NewCenter(g.flagImage),
+	)
+}
+
+// setupListView builds the "name them all" board, using the same numbered
+// answer list as the list game
+func (g *Game) setupListView() {
+	g.listProgress = widget.NewLabel("")
+
+	g.guessEntry = widget.NewEntry()
+	g.guessEntry.SetPlaceHolder(lang.X("game.list.enter_country", "Enter country name..."))
+	g.guessEntry.OnSubmitted = func(text string) { g.makeListGuess() }
+
+	guessBtn := components.NewButton(lang.X("game.list.guess", "Guess"), g.makeListGuess)
+
+	g.countryList = components.NewAnswerList(
+		func() int { return len(g.listCountries) },
+		func(id int) (string, bool) {
+			country := g.listCountries[id]
+			return country.Name.Common, g.listGuessed[country.CCA2]
+		},
+	)
+
+	g.doneBtn = components.NewButton(lang.X("game.languages.done", "Done"), g.finishListRound)
+	g.nextBtn = components.NewButton(lang.X("game.higher_lower.next_round", "Next Round"), g.newGame)
+	g.nextBtn.Hide()
+
+	guessContainer := container.NewBorder(
+		nil, nil,
+		guessBtn, container.NewHBox(g.doneBtn, g.nextBtn),
+		g.guessEntry,
+	)
+
+	g.listView = container.NewBorder(
+		container.NewVBox(g.listProgress, guessContainer), nil, nil, nil,
+		g.countryList.GetContainer(),
+	)
+}
+
+func (g *Game) newGame() {
+	if len(g.countries) == 0 {
+		g.statusLabel.SetText(lang.X("error.loading_countries", "Error loading countries data"))
+		return
+	}
+	if g.total >= totalRounds {
+		return
+	}
+	if g.total == 0 {
+		g.startTime = time.Now()
+		plan, err := rounds.New(g.countries, rounds.Config{Rounds: totalRounds})
+		if err != nil {
+			g.statusLabel.SetText(rounds.ErrorText(err))
+			return
+		}
+		g.plan = plan
+	}
+
+	g.question = questionType(rand.Intn(2))
+	if (g.total+1)%listEvery == 0 {
+		g.question = listCountries
+	}
+
+	if g.question == listCountries {
+		g.startListRound()
+		return
+	}
+
+	// List rounds leave their planned country unused; a pool smaller than
+	// the round count repeats countries rather than running out
+	country := &g.plan.Rounds[g.total%g.plan.Len()].Answer
+	g.currentCountry = country
+
+	own := sortedKeys(country.Languages)
+	g.currentLanguage = own[rand.Intn(len(own))]
+
+	switch g.question {
+	case countryToLanguage:
+		g.buildLanguageOptions()
+		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.languages.question_language", "Which language is official in %s?"), country.Name.Common))
+		g.displayFlag()
+	case languageToCountry:
+		g.buildCountryOptions()
+		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.languages.question_country", "Which of these countries has %s as an official language?"), g.languageName(g.currentLanguage)))
+		g.flagImage.Resource = nil
+		g.flagImage.Refresh()
+	}
+
+	rand.Shuffle(len(g.options), func(i, j int) {
+		g.options[i], g.options[j] = g.options[j], g.options[i]
+	})
+	g.createButtons()
+	g.showView(g.choiceView)
+}
+
+// buildLanguageOptions uses the current language as the answer and three
+// languages that are not official in the current country as distractors
+func (g *Game) buildLanguageOptions() {
+	g.options = []option{{label: g.languageName(g.currentLanguage), code: g.currentLanguage}}
+
+	var candidates []string
+	for code := range g.languageNames {
+		if _, official := g.currentCountry.Languages[code]; !official {
+			candidates = append(candidates, code)
+		}
+	}
+	sort.Strings(candidates)
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	for _, code := range candidates {
+		if len(g.options) == 4 {
+			break
+		}
+		g.options = append(g.options, option{label: g.languageName(code), code: code})
+	}
+}
+
+// buildCountryOptions uses the current country as the answer and three
+// countries where the current language is not official as distractors
+func (g *Game) buildCountryOptions() {
+	g.options = []option{{label: g.currentCountry.Name.Common, code: g.currentCountry.CCA2}}
+
+	for _, i := range rand.Perm(len(g.countries)) {
+		if len(g.options) == 4 {
+			break
+		}
+		candidate := g.countries[i]
+		if g.languageUsers[g.currentLanguage][candidate.CCA2] {
+			continue
+		}
+		g.options = append(g.options, option{label: candidate.Name.Common, code: candidate.CCA2})
+	}
+}
+
+// languageName returns the language name in the current UI locale
+func (g *Game) languageName(code string) string {
+	return utils.TranslateLanguage(code, g.languageNames[code])
+}
+
+// isValid reports whether an option is an acceptable answer
+func (g *Game) isValid(opt option) bool {
+	if g.question == countryToLanguage {
+		_, ok := g.currentCountry.Languages[opt.code]
+		return ok
+	}
+	return g.languageUsers[g.currentLanguage][opt.code]
+}
+
+func (g *Game) displayFlag() {
+	if flagResource, err := assets.LoadFlagResource(g.currentCountry.CCA2); err == nil {
+		g.flagImage.Resource = flagResource
+	}
+	g.flagImage.Refresh()
+}
+
+func (g *Game) createButtons() {
+	g.buttonGrid.RemoveAll()
+
+	g.buttons = make([]*components.ColoredButton, len(g.options))
+	for i, opt := range g.options {
+		opt := opt
+		btn := components.NewColoredButton(opt.label, func() {
+			g.makeGuess(opt)
+		})
+		g.buttons[i] = btn
+		g.buttonGrid.Add(btn)
+	}
+	g.buttonGrid.Refresh()
+}
+
+func (g *Game) makeGuess(guessed option) {
+	isCorrect := g.isValid(guessed)
+	g.statusLabel.SetText(g.resultText(guessed, isCorrect))
+
+	for i, btn := range g.buttons {
+		if g.isValid(g.options[i]) {
+			btn.SetBgColor(components.CorrectAnswerColor)
+		} else {
+			btn.SetBgColor(components.WrongAnswerColor)
+		}
+		btn.Disable()
+	}
+
+	g.finishRound(isCorrect)
+	if g.total < totalRounds {
+		time.AfterFunc(1500*time.Millisecond, func() {
+			fyne.Do(func() {
+				g.newGame()
+			})
+		})
+	}
+}
+
+func (g *Game) resultText(guessed option, isCorrect bool) string {
+	languageName := g.languageName(g.currentLanguage)
+	if g.question == countryToLanguage {
+		var names []string
+		for _, code := range sortedKeys(g.currentCountry.Languages) {
+			names = append(names, g.languageName(code))
+		}
+		languageName = strings.Join(names, ", ")
+		guessed.label = g.currentCountry.Name.Common
+	}
+
+	if isCorrect {
+		return fmt.Sprintf(lang.X("game.languages.correct", "Correct! %s: %s"), guessed.label, languageName)
+	}
+	if g.question == countryToLanguage {
+		return fmt.Sprintf(lang.X("game.languages.wrong", "Wrong! %s: %s"), guessed.label, languageName)
+	}
+	return fmt.Sprintf(lang.X("game.languages.wrong_country", "Wrong! %s is not an official language in %s"), languageName, guessed.label)
+}
+
+// startListRound picks a language spoken in a handful of countries and asks
+// the player to name all of them
+func (g *Game) startListRound() {
+	var candidates []string
+	for code, users := range g.languageUsers {
+		if len(users) >= minListCountries && len(users) <= maxListCountries {
+			candidates = append(candidates, code)
+		}
+	}
+	sort.Strings(candidates)
+	g.currentLanguage = candidates[rand.Intn(len(candidates))]
+
+	g.listCountries = []models.Country{}
+	for _, country := range g.countries {
+		if g.languageUsers[g.currentLanguage][country.CCA2] {
+			g.listCountries = append(g.listCountries, country)
+		}
+	}
+	sort.Slice(g.listCountries, func(i, j int) bool {
+		return g.listCountries[i].Name.Common < g.listCountries[j].Name.Common
+	})
+	g.listGuessed = make(map[string]bool)
+	g.listRevealed = false
+
+	g.statusLabel.SetText(fmt.Sprintf(lang.X("game.languages.question_list", "Name all countries where %s is an official language!"), g.languageName(g.currentLanguage)))
+	g.updateListProgress()
+	g.guessEntry.SetText("")
+	g.guessEntry.Enable()
+	g.doneBtn.Show()
+	g.nextBtn.Hide()
+	g.countryList.SetRevealed(false)
+	g.showView(g.listView)
+}
+
+func (g *Game) makeListGuess() {
+	guess := strings.TrimSpace(g.guessEntry.Text)
+	if guess == "" || g.listRevealed {
+		return
+	}
+
+	found := false
+	for _, country := range g.listCountries {
+		if !g.listGuessed[country.CCA2] && utils.MatchCountry(guess, country, utils.MatchCommon|utils.MatchOfficial) {
+			g.listGuessed[country.CCA2] = true
+			found = true
+			break
+		}
+	}
+
+	g.guessEntry.SetText("")
+	if !found {
+		g.listProgress.SetText(lang.X("game.list.not_found", "Not found or already guessed. Try again!"))
+		return
+	}
+
+	if len(g.listGuessed) == len(g.listCountries) {
+		g.finishListRound()
+		return
+	}
+	g.updateListProgress()
+	g.countryList.Refresh()
+}
+
+// finishListRound reveals the remaining countries. The round counts as
+// correct when at least half of the countries were named.
+func (g *Game) finishListRound() {
+	if g.listRevealed {
+		return
+	}
+	g.listRevealed = true
+	g.guessEntry.Disable()
+	g.doneBtn.Hide()
+	if g.total+1 < totalRounds {
+		g.nextBtn.Show()
+	}
+	g.countryList.SetRevealed(true)
+	g.updateListProgress()
+
+	isCorrect := len(g.listGuessed)*2 >= len(g.listCountries)
+	g.statusLabel.SetText(fmt.Sprintf(lang.X("game.languages.list_result", "You named %d of %d countries where %s is official."), len(g.listGuessed), len(g.listCountries), g.languageName(g.currentLanguage)))
+	g.finishRound(isCorrect)
+}
+
+func (g *Game) updateListProgress() {
+	g.listProgress.SetText(fmt.Sprintf(lang.X("game.languages.list_progress", "%d/%d countries found"), len(g.listGuessed), len(g.listCountries)))
+}
+
+// finishRound records the round result and saves the score after the last round
+func (g *Game) finishRound(isCorrect bool) {
+	g.total++
+	if isCorrect {
+		g.score++
+	}
+	g.gameProgress.UpdateProgress(g.total, totalRounds, g.score)
+
+	if g.total < totalRounds {
+		return
+	}
+
+	finalPercent := float64(g.score) / totalRounds * 100
+	utils.SaveScore(utils.ScoreEntry{
+		GameMode: "languages",
+		Score:    g.score,
+		Total:    totalRounds,
+		Percent:  finalPercent,
+		Duration: int(time.Since(g.startTime).Seconds()),
+	})
+
+	time.AfterFunc(1500*time.Millisecond, func() {
+		fyne.Do(func() {
+			g.statusLabel.SetText(lang.L("game.complete", map[string]any{"Score": g.score, "Percent": int(finalPercent)}))
+		})
+	})
+}
+
+func (g *Game) showView(view *fyne.Container) {
+	g.mainContent.RemoveAll()
+	g.mainContent.Add(view)
+	g.mainContent.Refresh()
+}
+
+func sortedKeys(languages map[string]string) []string {
+	keys := make([]string, 0, len(languages))
+	for code := range languages {
+		keys = append(keys, code)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (g *Game) GetContent() *fyne.Container {
+	return g.content
+}
+
+func (g *Game) Start() {
+	g.newGame()
+}
+
+func (g *Game) Reset() {
+	g.score = 0
+	g.total = 0
+	g.gameProgress.Reset()
+	g.newGame()
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -15,20 +16,38 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
+// timeLimits are the countdown presets; 0 plays without a clock
+var timeLimits = []time.Duration{0, 5 * time.Minute, 10 * time.Minute, 15 * time.Minute}
+
 type Game struct {
 	content           *fyne.Container
 	backFunc          func()
 	selectionView     *fyne.Container
 	gameView          *fyne.Container
 	mainContent       *fyne.Container
-	selectedContinent string
+	target            target
+	capitals          bool // list capitals instead of country names
+	useMap            bool // fill in a map instead of the numbered list
 	allCountries      []models.Country
 	guessedCountries  map[string]bool
 	guessEntry    *widget.Entry
+	guessBtn      *components.Button
+	giveUpBtn     *components.Button
+	reviewBtn     *components.Button
 	progressLabel *widget.Label
-	countryList   *widget.List
+	countryList   *components.AnswerList
+	mapBoard      *mapBoard
+	board         *fyne.Container // the list or map, or the missed countries once reviewed
+	missedView    *fyne.Container
+	missedGroups  *fyne.Container
+	missedFilter  *widget.Entry
+	reviewing     bool
 	statusLabel   *widget.Label
 	gameProgress  *components.GameProgress
+	countdown     *components.Countdown
+	timeLimit     time.Duration
+	startTime     time.Time
+	finished      bool // the answers are revealed and the score saved
 }
 
 func NewGame(backFunc func()) *Game {
@@ -40,7 +59,12 @@ func NewGame(backFunc func()) *Game {
 }
 
 func (g *Game) setupUI() {
-	topBar := components.NewTopBar(lang.X("game.list.title", "List All Countries"), g.backFunc, g.Reset)
+	// Leaving mid-game must not let the clock run out and save in the background
+	back := func() {
+		g.countdown.Stop()
+		g.backFunc()
+	}
+	topBar := components.NewTopBar(lang.X("game.list.title", "List All Countries"), back, g.Reset)
 
 	g.setupSelectionView()
 	g.setupGameView()
@@ -54,44 +78,95 @@ func (g *Game) setupUI() {
 }
 
 func (g *Game) setupSelectionView() {
-	availableRegions := g.getAvailableRegions()
-	regionSelector := components.NewRegionSelector(
-		lang.X("game.list.select_region", "Select Region"),
-		lang.X("game.list.choose_region", "Choose a region and try to name all countries in it!"),
-		availableRegions,
-		g.startGame,
-	)
-	g.selectionView = regionSelector.GetContainer()
-}
-
-func (g *Game) getAvailableRegions() []string {
-	countries := data.LoadCountries()
-	regionMap := make(map[string]bool)
-	regionMap["World"] = true
-
-	for _, country := range countries {
-		if country.Region != "" {
-			regionMap[country.Region] = true
+	limitLabels := make([]string, len(timeLimits))
+	for i, limit := range timeLimits {
+		if limit == 0 {
+			limitLabels[i] = lang.X("game.list.no_limit", "No time limit")
+		} else {
+			limitLabels[i] = fmt.Sprintf(lang.X("game.list.minutes", "%d min"), int(limit.Minutes()))
 		}
 	}
+	limitSelector := widget.NewRadioGroup(limitLabels, func(selected string) {
+		for i, label := range limitLabels {
+			if label == selected {
+				g.timeLimit = timeLimits[i]
+			}
+		}
+	})
+	limitSelector.Horizontal = true
+	limitSelector.Required = true
+	limitSelector.SetSelected(limitLabels[0])
 
-	var regions []string
-	for region := range regionMap {
-		regions = append(regions, region)
+	answerLabels := []string{
+		lang.X("game.list.answer_countries", "Countries"),
+		lang.X("game.list.answer_capitals", "Capitals"),
 	}
+	answerSelector := widget.NewRadioGroup(answerLabels, func(selected string) {
+		g.capitals = selected == answerLabels[1]
+	})
+	answerSelector.Horizontal = true
+	answerSelector.Required = true
+	answerSelector.SetSelected(answerLabels[0])
 
-	// Sort with World first
-	sort.Slice(regions, func(i, j int) bool {
-		if regions[i] == "World" {
-			return true
-		}
-		if regions[j] == "World" {
-			return false
+	boardLabels := []string{
+		lang.X("game.list.board_list", "List"),
+		lang.X("game.list.board_map", "Map"),
+	}
+	boardSelector := widget.NewRadioGroup(boardLabels, func(selected string) {
+		g.useMap = selected == boardLabels[1]
+	})
+	boardSelector.Horizontal = true
+	boardSelector.Required = true
+	boardSelector.SetSelected(boardLabels[0])
+
+	// Letter rounds span the whole world
+	letterSelector := widget.NewSelect(startingLetters(data.LoadCountries()), nil)
+	letterSelector.PlaceHolder = lang.X("game.list.pick_letter", "Or pick a starting letter...")
+	letterSelector.OnChanged = func(letter string) {
+		if letter == "" {
+			return
 		}
-		return regions[i] < regions[j]
+		g.startGame(target{region: "World", letter: []rune(letter)[0], capitals: g.capitals})
+		letterSelector.ClearSelected()
+	}
+
+	regions, subregions := g.getAvailableRegions()
+	regionSelector := components.NewRegionSelectorWithConfig(components.RegionSelectorConfig{
+		Title:       lang.X("game.list.select_region", "Select Region"),
+		Description: lang.X("game.list.choose_region", "Choose a region or subregion and try to name all countries in it!"),
+		Regions:     regions,
+		Subregions:  subregions,
+		OnRegionSelected: func(region string) {
+			g.startGame(target{region: region, capitals: g.capitals})
+		},
 	})
 
-	return regions
+	// The settings and the region list don't fit on small screens
+	g.selectionView = container.NewMax(container.NewVScroll(container.NewVBox(
+		limitSelector,
+		answerSelector,
+		boardSelector,
+		regionSelector.GetContainer(),
+		letterSelector,
+	)))
+}
+
+// getAvailableRegions returns the regions and the subregions within each
+func (g *Game) getAvailableRegions() ([]string, map[string][]string) {
+	regions := []string{"World"}
+	subregions := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, country := range data.LoadCountries() {
+		if country.Region != "" && !seen[country.Region] {
+			seen[country.Region] = true
+			regions = append(regions, country.Region)
+		}
+		if country.Subregion != "" && !seen[country.Subregion] {
+			seen[country.Subregion] = true
+			subregions[country.Region] = append(subregions[country.Region], country.Subregion)
+		}
+	}
+	return regions, subregions
 }
 
 func (g *Game) setupGameView() {
@@ -102,27 +177,25 @@ func (g *Game) setupGameView() {
 	g.guessEntry.SetPlaceHolder(lang.X("game.list.enter_country", "Enter country name..."))
 	g.guessEntry.OnSubmitted = func(text string) { g.makeGuess() }
 
-	guessBtn := components.NewButton(lang.X("game.list.guess", "Guess"), g.makeGuess)
+	g.guessBtn = components.NewButton(lang.X("game.list.guess", "Guess"), g.makeGuess)
+	g.giveUpBtn = components.NewButton(lang.X("game.list.give_up", "Give up"), func() { g.finish(false) })
+	g.reviewBtn = components.NewButton(lang.X("game.list.review_missed", "Review missed"), g.toggleMissed)
+	g.reviewBtn.Hide()
+
+	g.countdown = components.NewCountdown()
+	g.countdown.OnExpired = func() { g.finish(true) }
 
-	g.countryList = widget.NewList(
+	g.countryList = components.NewAnswerList(
 		func() int { return len(g.allCountries) },
-		func() fyne.CanvasObject {
-			return widget.NewLabel("")
-		},
-		func(id widget.ListItemID, obj fyne.CanvasObject) {
-			label := obj.(*widget.Label)
+		func(id int) (string, bool) {
 			country := g.allCountries[id]
-			if g.guessedCountries[strings.ToLower(country.Name.Common)] {
-				label.SetText(fmt.Sprintf(lang.X("game.list.country_item", "%d. %s"), id+1, country.Name.Common))
-			} else {
-				label.SetText(fmt.Sprintf(lang.X("game.list.country_unknown", "%d. ?"), id+1))
-			}
+			return g.target.answer(country), g.named(country)
 		},
 	)
 
 	guessContainer := container.NewBorder(
 		nil, nil,
-		guessBtn, nil,
+		g.guessBtn, g.giveUpBtn,
 		g.guessEntry,
 	)
 
@@ -135,42 +208,89 @@ func (g *Game) setupGameView() {
 
 	gameHeader := container.NewVBox(
 		g.gameProgress.GetContainer(),
-		g.progressLabel,
+		container.NewBorder(nil, nil, nil, g.countdown.GetContainer(), g.progressLabel),
 		g.statusLabel,
 		guessContainer,
+		g.reviewBtn,
 	)
 
+	g.setupMissedView()
+	g.mapBoard = newMapBoard()
+	g.board = container.NewMax(g.countryList.GetContainer())
+
 	g.gameView = container.NewBorder(
 		gameHeader, nil, nil, nil,
-		g.countryList,
+		g.board,
 	)
 }
 
-func (g *Game) startGame(continent string) {
-	g.selectedContinent = continent
+func (g *Game) startGame(t target) {
+	g.target = t
 	countries := data.LoadCountries()
 
 	g.allCountries = []models.Country{}
 	for _, country := range countries {
-		if continent == "World" || country.Region == continent {
+		if t.includes(country) {
 			g.allCountries = append(g.allCountries, country)
 		}
 	}
 
 	sort.Slice(g.allCountries, func(i, j int) bool {
-		return g.allCountries[i].Name.Common < g.allCountries[j].Name.Common
+		return t.answer(g.allCountries[i]) < t.answer(g.allCountries[j])
 	})
 	g.guessedCountries = make(map[string]bool)
+	g.finished = false
+	g.startTime = time.Now()
 
 	g.updateProgress()
-	g.statusLabel.SetText(lang.X("game.list.start_guessing", "Start guessing countries!"))
 	g.gameProgress.UpdateProgress(0, len(g.allCountries), 0)
 	g.guessEntry.SetText("")
+	if t.capitals {
+		g.guessEntry.SetPlaceHolder(lang.X("game.list.enter_capital", "Enter capital name..."))
+	} else {
+		g.guessEntry.SetPlaceHolder(lang.X("game.list.enter_country", "Enter country name..."))
+	}
+	g.giveUpBtn.Show()
+	g.reviewBtn.Hide()
+	g.reviewing = false
+	g.reviewBtn.SetText(lang.X("game.list.review_missed", "Review missed"))
+
+	if g.useMap {
+		g.mapBoard.load(countries, g.allCountries, t.region == "World" && t.letter == 0, func() {
+			// Guesses made while the map loaded are drawn in this first render
+			g.mapBoard.render(g.named, g.finished)
+		})
+	}
+	g.showBoard(g.boardView())
 
 	g.mainContent.RemoveAll()
 	g.mainContent.Add(g.gameView)
 	g.mainContent.Refresh()
-	g.countryList.Refresh()
+	g.countryList.SetRevealed(false)
+
+	g.countdown.Stop()
+	g.countdown.GetContainer().Hide()
+	if len(g.allCountries) == 0 {
+		// A letter no capital starts with, for instance
+		g.finished = true
+		g.statusLabel.SetText(lang.X("game.list.no_targets", "Nothing to list here. Pick another region or letter."))
+		g.guessEntry.Disable()
+		g.guessBtn.Disable()
+		g.giveUpBtn.Hide()
+		return
+	}
+
+	if t.capitals {
+		g.statusLabel.SetText(lang.X("game.list.start_capitals", "Start guessing capitals!"))
+	} else {
+		g.statusLabel.SetText(lang.X("game.list.start_guessing", "Start guessing countries!"))
+	}
+	g.guessEntry.Enable()
+	g.guessBtn.Enable()
+	if g.timeLimit > 0 {
+		g.countdown.Start(g.timeLimit)
+		g.countdown.GetContainer().Show()
+	}
 }
 
 func (g *Game) makeGuess() {
@@ -183,7 +303,7 @@ func (g *Game) makeGuess() {
 	var matchedCountry models.Country
 
 	for _, country := range g.allCountries {
-		if utils.MatchCountry(guess, country, utils.MatchCommon|utils.MatchOfficial) && !g.guessedCountries[strings.ToLower(country.Name.Common)] {
+		if g.target.matches(guess, country) && !g.named(country) {
 			g.guessedCountries[strings.ToLower(country.Name.Common)] = true
 			matchedCountry = country
 			found = true
@@ -192,11 +312,17 @@ func (g *Game) makeGuess() {
 	}
 
 	if found {
-		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.list.correct_added", "Correct! %s added to the list."), matchedCountry.Name.Common))
+		if g.useMap {
+			g.mapBoard.fill(matchedCountry)
+			g.statusLabel.SetText(fmt.Sprintf(lang.X("game.list.correct_map", "Correct! %s is on the map."), g.target.answer(matchedCountry)))
+		} else {
+			g.statusLabel.SetText(fmt.Sprintf(lang.X("game.list.correct_added", "Correct! %s added to the list."), g.target.answer(matchedCountry)))
+		}
 		g.updateProgress()
 		g.gameProgress.UpdateProgress(len(g.guessedCountries), len(g.allCountries), len(g.guessedCountries))
 		if len(g.guessedCountries) == len(g.allCountries) {
-			g.statusLabel.SetText(lang.X("game.list.congratulations", "Congratulations! You've listed all countries!"))
+			g.finish(false)
+			g.statusLabel.SetText(lang.X("game.list.congratulations", "Congratulations! You've listed them all!"))
 		}
 	} else {
 		g.statusLabel.SetText(lang.X("game.list.not_found", "Not found or already guessed. Try again!"))
@@ -206,12 +332,92 @@ func (g *Game) makeGuess() {
 	g.countryList.Refresh()
 }
 
+// named reports whether the player has named the country or its capital
+func (g *Game) named(country models.Country) bool {
+	return g.guessedCountries[strings.ToLower(country.Name.Common)]
+}
+
+// finish ends the game, after a give up, when the time runs out or when
+// every country is listed. Missing names are revealed and the score saved.
+func (g *Game) finish(timeUp bool) {
+	if g.finished {
+		return
+	}
+	g.finished = true
+	g.countdown.Stop()
+	g.guessEntry.Disable()
+	g.guessBtn.Disable()
+	g.giveUpBtn.Hide()
+
+	found := len(g.guessedCountries)
+	total := len(g.allCountries)
+	duration := time.Since(g.startTime)
+	if g.timeLimit > 0 {
+		duration = g.countdown.Elapsed()
+	}
+	utils.SaveScore(utils.ScoreEntry{
+		GameMode: "list",
+		Score:    found,
+		Total:    total,
+		Percent:  float64(found) / float64(total) * 100,
+		Duration: int(duration.Seconds()),
+		Region:   g.target.region,
+		Variant:  g.variant(),
+	})
+
+	if timeUp {
+		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.list.time_up", "Time's up! You listed %d of %d."), found, total))
+	} else {
+		g.statusLabel.SetText(fmt.Sprintf(lang.X("game.list.gave_up", "You listed %d of %d. The missing ones are shown in red."), found, total))
+	}
+	if found < total {
+		g.reviewBtn.Show()
+	}
+	if g.useMap {
+		g.mapBoard.render(g.named, true)
+	}
+	// Revealed after giving up or running out of time
+	g.countryList.SetRevealed(true)
+}
+
+// variant describes the target, the board and the time limit for the
+// scoreboard
+func (g *Game) variant() string {
+	parts := g.target.variant()
+	if g.useMap {
+		parts = append(parts, "map")
+	}
+	if g.timeLimit > 0 {
+		parts = append(parts, fmt.Sprintf("%d min", int(g.timeLimit.Minutes())))
+	}
+	return strings.Join(parts, " ")
+}
+
+// boardView is the list or the map, whichever the game is played on
+func (g *Game) boardView() fyne.CanvasObject {
+	if g.useMap {
+		return g.mapBoard.image
+	}
+	return g.countryList.GetContainer()
+}
+
+// showBoard puts the list, the map or the missed countries below the header
+func (g *Game) showBoard(view fyne.CanvasObject) {
+	g.board.RemoveAll()
+	g.board.Add(view)
+	g.board.Refresh()
+}
+
 func (g *Game) updateProgress() {
-	translatedRegion := utils.TranslateRegion(g.selectedContinent)
-	g.progressLabel.SetText(fmt.Sprintf(lang.X("game.list.progress", "%s: %d/%d countries found"), translatedRegion, len(g.guessedCountries), len(g.allCountries)))
+	if g.target.capitals {
+		g.progressLabel.SetText(fmt.Sprintf(lang.X("game.list.progress_capitals", "%s: %d/%d capitals found"), g.target.title(), len(g.guessedCountries), len(g.allCountries)))
+		return
+	}
+	g.progressLabel.SetText(fmt.Sprintf(lang.X("game.list.progress", "%s: %d/%d countries found"), g.target.title(), len(g.guessedCountries), len(g.allCountries)))
 }
 
 func (g *Game) showSelection() {
+	g.countdown.Stop()
 	g.mainContent.RemoveAll()
 	g.mainContent.Add(g.selectionView)
 	g.mainContent.Refresh()
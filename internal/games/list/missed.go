@@ -0,0 +1,92 @@
+package list
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/utils"
+
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+func (g *Game) setupMissedView() {
+	g.missedGroups = container.NewVBox()
+
+	g.missedFilter = widget.NewEntry()
+	g.missedFilter.SetPlaceHolder(lang.X("game.list.filter_missed", "Filter by name or subregion..."))
+	g.missedFilter.OnChanged = func(string) { g.renderMissed() }
+
+	g.missedView = container.NewBorder(
+		g.missedFilter, nil, nil, nil,
+		container.NewVScroll(g.missedGroups),
+	)
+}
+
+// toggleMissed switches the board between the full list or map and the
+// missed countries
+func (g *Game) toggleMissed() {
+	if g.reviewing {
+		g.reviewing = false
+		g.reviewBtn.SetText(lang.X("game.list.review_missed", "Review missed"))
+		g.showBoard(g.boardView())
+		return
+	}
+	g.reviewing = true
+	if g.useMap {
+		g.reviewBtn.SetText(lang.X("game.list.back_to_map", "Back to the map"))
+	} else {
+		g.reviewBtn.SetText(lang.X("game.list.back_to_list", "Back to the list"))
+	}
+	g.missedFilter.SetText("")
+	g.renderMissed()
+	g.showBoard(g.missedView)
+}
+
+// renderMissed lists the countries not named, grouped under their
+// subregion, keeping those whose name or subregion contains the filter
+func (g *Game) renderMissed() {
+	filter := strings.ToLower(strings.TrimSpace(g.missedFilter.Text))
+
+	groups := make(map[string][]models.Country)
+	for _, country := range g.allCountries {
+		if g.named(country) {
+			continue
+		}
+		subregion := country.Subregion
+		if subregion == "" {
+			subregion = country.Region
+		}
+		subregion = utils.TranslateRegion(subregion)
+		if filter != "" &&
+			!strings.Contains(strings.ToLower(g.target.answer(country)), filter) &&
+			!strings.Contains(strings.ToLower(subregion), filter) {
+			continue
+		}
+		groups[subregion] = append(groups[subregion], country)
+	}
+
+	subregions := make([]string, 0, len(groups))
+	for subregion := range groups {
+		subregions = append(subregions, subregion)
+	}
+	sort.Strings(subregions)
+
+	g.missedGroups.RemoveAll()
+	if len(subregions) == 0 {
+		g.missedGroups.Add(widget.NewLabel(lang.X("game.list.no_missed", "No missed countries match.")))
+	}
+	for _, subregion := range subregions {
+		countries := groups[subregion]
+		header := widget.NewLabel(fmt.Sprintf(lang.X("game.list.missed_group", "%s (%d)"), subregion, len(countries)))
+		header.TextStyle.Bold = true
+		g.missedGroups.Add(header)
+		for _, country := range countries {
+			g.missedGroups.Add(widget.NewLabel(g.target.answer(country)))
+		}
+	}
+	g.missedGroups.Refresh()
+}
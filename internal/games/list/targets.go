@@ -0,0 +1,101 @@
+package list
+
+import (
+	"fmt"
+	"unicode"
+
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/utils"
+
+	"fyne.io/fyne/v2/lang"
+)
+
+// target is what the player has to list: the countries or capitals of the
+// world, a region or a subregion, optionally only those starting with a letter
+type target struct {
+	region   string // "World", a region or a subregion
+	letter   rune   // 0 for any letter
+	capitals bool
+}
+
+// includes reports whether a country is part of the target
+func (t target) includes(country models.Country) bool {
+	if t.region != "World" && country.Region != t.region && country.Subregion != t.region {
+		return false
+	}
+	answer := t.answer(country)
+	if answer == "" {
+		return false
+	}
+	if t.letter != 0 {
+		first := []rune(answer)[0]
+		return unicode.ToUpper(first) == t.letter
+	}
+	return true
+}
+
+// answer is the name the player has to come up with for a country
+func (t target) answer(country models.Country) string {
+	if !t.capitals {
+		return country.Name.Common
+	}
+	if len(country.Capital) == 0 {
+		return ""
+	}
+	return country.Capital[0]
+}
+
+// matches reports whether guess names the country's answer. Capitals accept
+// any of a country's capitals.
+func (t target) matches(guess string, country models.Country) bool {
+	if !t.capitals {
+		return utils.MatchCountry(guess, country, utils.MatchCommon|utils.MatchOfficial)
+	}
+	for _, capital := range country.Capital {
+		if utils.MatchesCountryByName(guess, capital) {
+			return true
+		}
+	}
+	return false
+}
+
+// title names the target in the progress line
+func (t target) title() string {
+	if t.letter != 0 {
+		return fmt.Sprintf(lang.X("game.list.starting_with", "Starting with %s"), string(t.letter))
+	}
+	return utils.TranslateRegion(t.region)
+}
+
+// variant describes the target for the scoreboard, leaving out the default
+// of listing country names
+func (t target) variant() []string {
+	var parts []string
+	if t.capitals {
+		parts = append(parts, "capitals")
+	}
+	if t.letter != 0 {
+		parts = append(parts, "letter "+string(t.letter))
+	}
+	return parts
+}
+
+// startingLetters returns the letters at least one country or capital name
+// starts with
+func startingLetters(countries []models.Country) []string {
+	seen := make(map[rune]bool)
+	for _, country := range countries {
+		for _, name := range append([]string{country.Name.Common}, country.Capital...) {
+			if runes := []rune(name); len(runes) > 0 {
+				seen[unicode.ToUpper(runes[0])] = true
+			}
+		}
+	}
+	var letters []string
+	for letter := 'A'; letter <= 'Z'; letter++ {
+		if seen[letter] {
+			letters = append(letters, string(letter))
+		}
+	}
+	return letters
+}
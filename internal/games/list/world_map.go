@@ -0,0 +1,300 @@
+package list
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sync"
+
+	"flagged-it/internal/data"
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/ui/rasterizer"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+)
+
+const (
+	mapWidth  = 960
+	mapHeight = 540
+	// mapPadding is how many degrees a regional map shows around its countries
+	mapPadding = 6
+	// minCountryPixels is the size below which a country is drawn as a dot,
+	// so microstates and small islands can still be seen
+	minCountryPixels = 5
+	dotRadius        = 3
+)
+
+var (
+	contextColor = color.RGBA{229, 231, 235, 255} // gray-200, countries outside the target
+	pendingColor = color.RGBA{156, 163, 175, 255} // gray-400, still to be named
+	namedColor   = color.RGBA{59, 130, 246, 255}  // blue-500
+	missedColor  = color.RGBA{239, 68, 68, 255}   // red-500, revealed at the end
+)
+
+// mapCountry is one country drawn on the board
+type mapCountry struct {
+	country models.Country
+	shape   *rasterizer.Shape
+	target  bool
+	// small countries are drawn as a dot at dotX, dotY instead
+	small      bool
+	dotX, dotY float64
+}
+
+// mapBoard is the alternative to the list: a world or regional map that
+// fills in each country as it's named, so the board gives away nothing about
+// the names still missing
+type mapBoard struct {
+	image     *canvas.Image
+	img       *image.RGBA // what image shows, painted over as countries are named
+	countries []mapCountry
+	frame     *rasterizer.Shape // not drawn, it only fixes what the map shows; nil while loading
+	opts      rasterizer.Options
+	loads     int // bumped per game so a slow load can't replace a newer one
+}
+
+func newMapBoard() *mapBoard {
+	b := &mapBoard{
+		image: canvas.NewImageFromResource(nil),
+		opts: rasterizer.Options{
+			Width:     mapWidth,
+			Height:    mapHeight,
+			Fit:       1,
+			AntiAlias: true,
+		},
+	}
+	b.image.FillMode = canvas.ImageFillContain
+	b.image.SetMinSize(fyne.NewSize(mapWidth/3, mapHeight/3))
+	return b
+}
+
+// load lays out the map for a new game in the background, as the first
+// game parses every country's outline, then calls ready on the UI goroutine.
+// The board stays blank until then.
+func (b *mapBoard) load(all, targets []models.Country, world bool, ready func()) {
+	b.loads++
+	load := b.loads
+	b.frame, b.countries, b.img = nil, nil, nil
+	b.image.Image = nil
+	b.image.Refresh()
+
+	go func() {
+		frame, countries := b.layout(all, targets, world)
+		fyne.Do(func() {
+			if load != b.loads {
+				return
+			}
+			b.frame, b.countries = frame, countries
+			ready()
+		})
+	}()
+}
+
+// layout places the countries on the map. The whole world is drawn for
+// context, framed around the targets unless they cover the world. It only
+// reads opts, so it can run off the UI goroutine.
+func (b *mapBoard) layout(all, targets []models.Country, world bool) (*rasterizer.Shape, []mapCountry) {
+	isTarget := make(map[string]bool, len(targets))
+	for _, country := range targets {
+		isTarget[country.CCA3] = true
+	}
+
+	minLon, maxLon, minLat, maxLat, unwrap := -180.0, 180.0, -58.0, 84.0, false
+	if !world {
+		minLon, maxLon, minLat, maxLat, unwrap = targetExtent(targets)
+	}
+	// An equirectangular projection, squeezed so the map's middle latitude
+	// keeps its proportions
+	scale := math.Cos((minLat + maxLat) / 2 * math.Pi / 180)
+	project := func(lon, lat float64) []float64 {
+		return []float64{lon * scale, lat}
+	}
+
+	frame := rasterizer.NewShape([][][][]float64{{{
+		project(minLon, minLat), project(maxLon, minLat), project(maxLon, maxLat), project(minLon, maxLat),
+	}}})
+
+	var countries []mapCountry
+	for _, country := range all {
+		coords := countryCoordinates(country)
+		if len(coords) == 0 && !isTarget[country.CCA3] {
+			continue
+		}
+		projected := make([][][][]float64, len(coords))
+		for i, polygon := range coords {
+			// Whole polygons move past 180°, so none is torn across the map
+			shift := 0.0
+			if unwrap && westOfMeridian(polygon) {
+				shift = 360
+			}
+			projected[i] = make([][][]float64, len(polygon))
+			for j, ring := range polygon {
+				projected[i][j] = make([][]float64, len(ring))
+				for k, point := range ring {
+					projected[i][j][k] = project(point[0]+shift, point[1])
+				}
+			}
+		}
+
+		// Targets without an outline still get their dot
+		c := mapCountry{country: country, shape: rasterizer.NewShape(projected), target: isTarget[country.CCA3]}
+		c.small = b.pixelSize(frame, projected) < minCountryPixels
+		if c.small && len(country.Latlng) >= 2 {
+			lon := country.Latlng[1]
+			if unwrap && lon < 0 {
+				lon += 360
+			}
+			point := project(lon, country.Latlng[0])
+			c.dotX, c.dotY = frame.PixelPoint(point[0], point[1], b.opts)
+		}
+		countries = append(countries, c)
+	}
+	return frame, countries
+}
+
+// targetExtent returns the area spanned by the targets' centres. Targets
+// either side of the antimeridian, as in Oceania, are kept together by
+// moving western longitudes past 180°.
+func targetExtent(targets []models.Country) (minLon, maxLon, minLat, maxLat float64, unwrap bool) {
+	extent := func(unwrap bool) (float64, float64, float64, float64) {
+		minLon, maxLon, minLat, maxLat := math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+		for _, country := range targets {
+			if len(country.Latlng) < 2 {
+				continue
+			}
+			lat, lon := country.Latlng[0], country.Latlng[1]
+			if unwrap && lon < 0 {
+				lon += 360
+			}
+			minLon, maxLon = math.Min(minLon, lon), math.Max(maxLon, lon)
+			minLat, maxLat = math.Min(minLat, lat), math.Max(maxLat, lat)
+		}
+		return minLon, maxLon, minLat, maxLat
+	}
+
+	minLon, maxLon, minLat, maxLat = extent(false)
+	if maxLon-minLon > 180 {
+		if uMinLon, uMaxLon, _, _ := extent(true); uMaxLon-uMinLon < maxLon-minLon {
+			minLon, maxLon, unwrap = uMinLon, uMaxLon, true
+		}
+	}
+	if math.IsInf(minLon, 0) {
+		return -180, 180, -58, 84, false
+	}
+	return minLon - mapPadding, maxLon + mapPadding, math.Max(minLat-mapPadding, -85), math.Min(maxLat+mapPadding, 85), unwrap
+}
+
+// westOfMeridian reports whether most of a polygon's outer ring lies at
+// negative longitudes
+func westOfMeridian(polygon [][][]float64) bool {
+	if len(polygon) == 0 || len(polygon[0]) == 0 {
+		return false
+	}
+	sum := 0.0
+	for _, point := range polygon[0] {
+		sum += point[0]
+	}
+	return sum < 0
+}
+
+// pixelSize is the larger side of the biggest polygon's bounding box on the
+// map. Archipelagos spread over the ocean are as small as their islands.
+func (b *mapBoard) pixelSize(frame *rasterizer.Shape, coords [][][][]float64) float64 {
+	size := 0.0
+	for _, polygon := range coords {
+		if len(polygon) == 0 {
+			continue
+		}
+		minX, maxX, minY, maxY := math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+		for _, point := range polygon[0] {
+			x, y := frame.PixelPoint(point[0], point[1], b.opts)
+			minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+			minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+		}
+		size = math.Max(size, math.Max(maxX-minX, maxY-minY))
+	}
+	return size
+}
+
+// render redraws the map, colouring each target country by whether it has
+// been named and, once the game is over, whether it was missed
+func (b *mapBoard) render(named func(models.Country) bool, finished bool) {
+	if b.frame == nil {
+		// Still loading; the load renders once it's done
+		return
+	}
+	colorFor := func(c mapCountry) color.RGBA {
+		switch {
+		case !c.target:
+			return contextColor
+		case named(c.country):
+			return namedColor
+		case finished:
+			return missedColor
+		}
+		return pendingColor
+	}
+
+	layers := []rasterizer.Layer{{Shape: b.frame, Focus: true}}
+	for _, c := range b.countries {
+		layers = append(layers, rasterizer.Layer{Shape: c.shape, Fill: colorFor(c)})
+	}
+	b.img = rasterizer.RenderLayers(layers, b.opts)
+
+	// Dots go on top so no neighbour hides them
+	for _, c := range b.countries {
+		if c.target && c.small {
+			rasterizer.DrawDot(b.img, c.dotX, c.dotY, dotRadius, colorFor(c))
+		}
+	}
+
+	b.image.Image = b.img
+	b.image.Refresh()
+}
+
+// fill colours one named country over the current map, which is much
+// quicker than drawing the whole world again after every guess
+func (b *mapBoard) fill(country models.Country) {
+	if b.img == nil {
+		return
+	}
+	for _, c := range b.countries {
+		if c.country.CCA3 != country.CCA3 {
+			continue
+		}
+		rasterizer.DrawLayers(b.img, []rasterizer.Layer{
+			{Shape: b.frame, Focus: true},
+			{Shape: c.shape, Fill: namedColor},
+		}, b.opts)
+		if c.small {
+			rasterizer.DrawDot(b.img, c.dotX, c.dotY, dotRadius, namedColor)
+		}
+		b.image.Refresh()
+		return
+	}
+}
+
+// geoCache keeps parsed outlines, which every new board needs again. Boards
+// load in the background, so it is guarded.
+var (
+	geoCache      = make(map[string][][][][]float64)
+	geoCacheMutex sync.Mutex
+)
+
+func countryCoordinates(country models.Country) [][][][]float64 {
+	geoCacheMutex.Lock()
+	coords, ok := geoCache[country.CCA3]
+	geoCacheMutex.Unlock()
+	if ok {
+		return coords
+	}
+
+	if geoData, err := data.LoadGeoData(country.CCA3); err == nil && len(geoData.Features) > 0 {
+		coords = geoData.Features[0].Geometry.Polygons()
+	}
+	geoCacheMutex.Lock()
+	geoCache[country.CCA3] = coords
+	geoCacheMutex.Unlock()
+	return coords
+}
@@ -0,0 +1,412 @@
+package ranking
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"flagged-it/internal/data"
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/games/rounds"
+	"flagged-it/internal/ui/components"
+	"flagged-it/internal/utils"
+	"flagged-it/pkg/assets"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	totalRounds      = 5
+	countriesPerRank = 5
+	rowHeight        = 56
+)
+
+type difficulty int
+
+const (
+	easy difficulty = iota
+	medium
+	hard
+)
+
+// windowSize is how many neighbouring countries (sorted by the metric) a
+// round is drawn from; smaller windows mean closer values
+func (d difficulty) windowSize() int {
+	switch d {
+	case medium:
+		return 40
+	case hard:
+		return 12
+	}
+	return 0
+}
+
+// rankRow is a single draggable country row in the ranking board
+type rankRow struct {
+	widget.BaseWidget
+	game       *Game
+	index      int
+	bg         *canvas.Rectangle
+	content    fyne.CanvasObject
+	dragOffset float32
+}
+
+func newRankRow(g *Game, index int, bg *canvas.Rectangle, content fyne.CanvasObject) *rankRow {
+	r := &rankRow{game: g, index: index, bg: bg, content: content}
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+func (r *rankRow) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewStack(r.bg, r.content))
+}
+
+func (r *rankRow) MinSize() fyne.Size {
+	return fyne.NewSize(r.BaseWidget.MinSize().Width, rowHeight)
+}
+
+func (r *rankRow) Dragged(e *fyne.DragEvent) {
+	r.dragOffset += e.Dragged.DY
+}
+
+func (r *rankRow) DragEnd() {
+	steps := int(math.Round(float64(r.dragOffset / rowHeight)))
+	r.dragOffset = 0
+	r.game.move(r.index, steps)
+}
+
+type Game struct {
+	content       *fyne.Container
+	backFunc      func()
+	mainContent   *fyne.Container
+	selectionView *fyne.Container
+	gameView      *fyne.Container
+	countries     []models.Country
+	metric        utils.CountryMetric
+	difficulty    difficulty
+	order         []models.Country
+	revealed      bool
+	rowsContainer *fyne.Container
+	statusLabel   *widget.Label
+	submitBtn     *components.Button
+	nextBtn       *components.Button
+	score         int
+	total         int
+	round         int
+	startTime     time.Time
+	gameProgress  *components.GameProgress
+}
+
+func NewGame(backFunc func()) *Game {
+	g := &Game{
+		backFunc:  backFunc,
+		countries: data.LoadCountries(),
+		metric:    utils.MetricPopulation,
+	}
+	g.setupUI()
+	return g
+}
+
+func (g *Game) setupUI() {
+	topBar := components.NewTopBar(lang.X("game.ranking.title", "Rank the Countries"), g.backFunc, g.Reset)
+
+	g.setupSelectionView()
+	g.setupGameView()
+
+	g.mainContent = container.NewMax(g.selectionView)
+
+	g.content = container.NewBorder(
+		topBar.GetContainer(), nil, nil, nil,
+		g.mainContent,
+	)
+}
+
+func (g *Game) setupSelectionView() {
+	titleLabel := widget.NewLabel(lang.X("game.ranking.select_metric", "Select Metric"))
+	titleLabel.TextStyle.Bold = true
+
+	descLabel := widget.NewLabel(lang.X("game.ranking.description", "Put five countries in order from highest to lowest!"))
+	descLabel.Wrapping = fyne.TextWrapWord
+
+	difficultyLabels := []string{
+		lang.X("difficulty.easy", "Easy"),
+		lang.X("difficulty.medium", "Medium"),
+		lang.X("difficulty.hard", "Hard"),
+	}
+	difficultyRadio := widget.NewRadioGroup(difficultyLabels, func(selected string) {
+		for i, label := range difficultyLabels {
+			if label == selected {
+				g.difficulty = difficulty(i)
+			}
+		}
+	})
+	difficultyRadio.Horizontal = true
+	difficultyRadio.Required = true
+	difficultyRadio.SetSelected(difficultyLabels[0])
+
+	columns := 2
+	if utils.IsMobile() {
+		columns = 1
+	}
+	buttonGrid := container.NewGridWithColumns(columns)
+	for _, metric := range utils.CountryMetrics {
+		metric := metric
+		buttonGrid.Add(components.NewButton(metric.Label(), func() {
+			g.startGame(metric)
+		}))
+	}
+
+	g.selectionView = container.NewVBox(
+		titleLabel,
+		descLabel,
+		difficultyRadio,
+		buttonGrid,
+	)
+}
+
+func (g *Game) setupGameView() {
+	g.statusLabel = widget.NewLabel("")
+	g.statusLabel.Wrapping = fyne.TextWrapWord
+
+	g.rowsContainer = container.NewVBox()
+
+	g.submitBtn = components.NewButton(lang.X("game.ranking.submit", "Submit"), g.submit)
+	g.submitBtn.Importance = widget.HighImportance
+	g.nextBtn = components.NewButton(lang.X("game.higher_lower.next_round", "Next Round"), g.nextRound)
+	g.nextBtn.Hide()
+
+	g.gameProgress = components.NewGameProgress(components.GameProgressConfig{
+		ShowRounds:      true,
+		ShowPercentage:  true,
+		ShowProgressBar: true,
+	})
+
+	header := container.NewVBox(
+		g.gameProgress.GetContainer(),
+		g.statusLabel,
+	)
+
+	g.gameView = container.NewBorder(
+		header,
+		container.NewVBox(g.submitBtn, g.nextBtn),
+		nil, nil,
+		container.NewVScroll(g.rowsContainer),
+	)
+}
+
+func (g *Game) startGame(metric utils.CountryMetric) {
+	g.metric = metric
+	g.score = 0
+	g.total = 0
+	g.round = 0
+	g.startTime = time.Now()
+	g.gameProgress.Reset()
+
+	g.mainContent.RemoveAll()
+	g.mainContent.Add(g.gameView)
+	g.mainContent.Refresh()
+
+	g.nextRound()
+}
+
+func (g *Game) nextRound() {
+	if g.round >= totalRounds {
+		return
+	}
+	g.round++
+	g.order = g.pickCountries()
+	g.revealed = false
+
+	g.statusLabel.SetText(fmt.Sprintf(lang.X("game.ranking.instructions", "Drag or move the countries to order them by %s, highest first."), g.metric.Label()))
+	g.submitBtn.Show()
+	g.nextBtn.Hide()
+	g.renderRows()
+}
+
+// pickCountries draws countries from a window of neighbours in the metric's
+// sorted order so harder difficulties get closer values
+func (g *Game) pickCountries() []models.Country {
+	var candidates []models.Country
+	for _, country := range g.countries {
+		if _, ok := g.metric.Value(country); ok {
+			candidates = append(candidates, country)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, _ := g.metric.Value(candidates[i])
+		b, _ := g.metric.Value(candidates[j])
+		return a < b
+	})
+
+	window := g.difficulty.windowSize()
+	if window > 0 && window < len(candidates) {
+		start := rand.Intn(len(candidates) - window + 1)
+		candidates = candidates[start : start+window]
+	}
+
+	plan, err := rounds.New(candidates, rounds.Config{Rounds: countriesPerRank})
+	if err != nil {
+		return nil
+	}
+	picked := make([]models.Country, 0, plan.Len())
+	for _, round := range plan.Rounds {
+		picked = append(picked, round.Answer)
+	}
+	return picked
+}
+
+// move shifts the country at index by steps positions (negative is up)
+func (g *Game) move(index, steps int) {
+	if g.revealed || steps == 0 {
+		return
+	}
+	target := index + steps
+	if target < 0 {
+		target = 0
+	}
+	if target >= len(g.order) {
+		target = len(g.order) - 1
+	}
+
+	country := g.order[index]
+	g.order = append(g.order[:index], g.order[index+1:]...)
+	g.order = append(g.order[:target], append([]models.Country{country}, g.order[target:]...)...)
+	g.renderRows()
+}
+
+func (g *Game) renderRows() {
+	g.rowsContainer.RemoveAll()
+
+	correct := g.correctOrder()
+	for i, country := range g.order {
+		i := i
+		rank := widget.NewLabel(fmt.Sprintf("%d.", i+1))
+		rank.TextStyle.Bold = true
+
+		flagImage := canvas.NewImageFromResource(nil)
+		if res, err := assets.LoadFlagResource(country.CCA2); err == nil {
+			flagImage.Resource = res
+		}
+		flagImage.FillMode = canvas.ImageFillContain
+		flagImage.SetMinSize(fyne.NewSize(48, 32))
+
+		name := widget.NewLabel(country.Name.Common)
+
+		bg := canvas.NewRectangle(color.Transparent)
+		var right fyne.CanvasObject
+		if g.revealed {
+			value, _ := g.metric.Value(country)
+			right = widget.NewLabel(g.metric.Format(value))
+
+			expected, _ := g.metric.Value(correct[i])
+			if value == expected {
+				bg.FillColor = components.CorrectAnswerColor
+			} else {
+				bg.FillColor = components.WrongAnswerColor
+			}
+		} else {
+			upBtn := components.NewButtonWithIcon("", theme.MoveUpIcon(), func() { g.move(i, -1) })
+			downBtn := components.NewButtonWithIcon("", theme.MoveDownIcon(), func() { g.move(i, 1) })
+			if i == 0 {
+				upBtn.Disable()
+			}
+			if i == len(g.order)-1 {
+				downBtn.Disable()
+			}
+			right = container.NewHBox(upBtn, downBtn)
+		}
+
+		content := container.NewBorder(nil, nil,
+			container.NewHBox(rank, flagImage),
+			right,
+			name,
+		)
+		g.rowsContainer.Add(newRankRow(g, i, bg, content))
+	}
+	g.rowsContainer.Refresh()
+}
+
+// correctOrder returns the current countries sorted by the metric, highest first
+func (g *Game) correctOrder() []models.Country {
+	sorted := append([]models.Country(nil), g.order...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, _ := g.metric.Value(sorted[i])
+		b, _ := g.metric.Value(sorted[j])
+		return a > b
+	})
+	return sorted
+}
+
+// concordantPairs counts the pairs the player ordered correctly, which is the
+// Kendall tau distance turned into partial credit. Ties count as correct.
+func (g *Game) concordantPairs() (int, int) {
+	concordant, pairs := 0, 0
+	for i := 0; i < len(g.order); i++ {
+		for j := i + 1; j < len(g.order); j++ {
+			a, _ := g.metric.Value(g.order[i])
+			b, _ := g.metric.Value(g.order[j])
+			pairs++
+			if a >= b {
+				concordant++
+			}
+		}
+	}
+	return concordant, pairs
+}
+
+func (g *Game) submit() {
+	if g.revealed {
+		return
+	}
+	g.revealed = true
+
+	concordant, pairs := g.concordantPairs()
+	g.score += concordant
+	g.total += pairs
+	g.gameProgress.UpdateProgressWithPercent(g.round, totalRounds, float64(g.score)/float64(g.total)*100)
+
+	g.statusLabel.SetText(fmt.Sprintf(lang.X("game.ranking.result", "You ordered %d of %d pairs correctly."), concordant, pairs))
+	g.submitBtn.Hide()
+	g.renderRows()
+
+	if g.round < totalRounds {
+		g.nextBtn.Show()
+		return
+	}
+
+	finalPercent := float64(g.score) / float64(g.total) * 100
+	utils.SaveScore(utils.ScoreEntry{
+		GameMode: "ranking",
+		Score:    g.score,
+		Total:    g.total,
+		Percent:  finalPercent,
+		Duration: int(time.Since(g.startTime).Seconds()),
+	})
+	g.statusLabel.SetText(fmt.Sprintf(lang.X("game.ranking.complete", "Game Complete! You ordered %d of %d pairs correctly (%.0f%%)"), g.score, g.total, finalPercent))
+}
+
+func (g *Game) showSelection() {
+	g.mainContent.RemoveAll()
+	g.mainContent.Add(g.selectionView)
+	g.mainContent.Refresh()
+}
+
+func (g *Game) GetContent() *fyne.Container {
+	return g.content
+}
+
+func (g *Game) Start() {
+	g.showSelection()
+}
+
+func (g *Game) Reset() {
+	g.showSelection()
+}
@@ -0,0 +1,174 @@
+package rounds
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"flagged-it/internal/data/models"
+
+	"fyne.io/fyne/v2/lang"
+)
+
+var (
+	// ErrEmptyPool means no country in the pool can be asked about
+	ErrEmptyPool = errors.New("no countries available for this selection")
+	// ErrPoolTooSmall means there are not enough countries for a multiple choice question
+	ErrPoolTooSmall = errors.New("not enough countries for multiple choice")
+)
+
+// DistractorFunc returns up to n preferred wrong options for answer, most
+// preferred first. The planner tops up with random countries from the pool.
+type DistractorFunc func(answer models.Country, pool []models.Country, n int) []models.Country
+
+// Config describes the rounds a game wants
+type Config struct {
+	// Rounds is the number of rounds to play; 0 plays every eligible country once
+	Rounds int
+	// Options is the number of choices per round including the answer; 0 for none
+	Options int
+	// Eligible limits which countries can be the answer; nil allows all
+	Eligible func(models.Country) bool
+	// Distractors picks preferred wrong options; nil picks them at random
+	Distractors DistractorFunc
+}
+
+// Round is one planned question
+type Round struct {
+	Answer models.Country
+	// Options holds the answer and its distractors in random order, or nil
+	// when the game asked for no options
+	Options []models.Country
+}
+
+// Plan is the full list of rounds for a game, computed up front so that a
+// small pool can never make a game loop forever looking for a new country
+type Plan struct {
+	Rounds  []Round
+	Wanted  int // rounds that were asked for
+	Options int // options per round that the pool allows
+	pool    []models.Country
+	answers []models.Country
+	config  Config
+}
+
+// New plans the rounds for pool. It returns fewer rounds or options than
+// requested when the pool is small; Notice explains that to the player.
+func New(pool []models.Country, config Config) (*Plan, error) {
+	var answers []models.Country
+	for _, country := range pool {
+		if config.Eligible == nil || config.Eligible(country) {
+			answers = append(answers, country)
+		}
+	}
+	if len(answers) == 0 {
+		return nil, ErrEmptyPool
+	}
+
+	options := config.Options
+	if options > len(pool) {
+		options = len(pool)
+	}
+	if config.Options > 0 && options < 2 {
+		return nil, ErrPoolTooSmall
+	}
+
+	p := &Plan{
+		Wanted:  config.Rounds,
+		Options: options,
+		pool:    pool,
+		answers: answers,
+		config:  config,
+	}
+	if p.Wanted == 0 {
+		p.Wanted = len(answers)
+	}
+	p.Extend()
+	if len(p.Rounds) > p.Wanted {
+		p.Rounds = p.Rounds[:p.Wanted]
+	}
+	return p, nil
+}
+
+// Extend appends another pass over every eligible country in a new random
+// order, for endless modes that outlast the pool
+func (p *Plan) Extend() {
+	order := rand.Perm(len(p.answers))
+	// Avoid asking the same country twice in a row across passes
+	if n := len(p.Rounds); n > 0 && len(order) > 1 && p.answers[order[0]].CCA2 == p.Rounds[n-1].Answer.CCA2 {
+		order[0], order[1] = order[1], order[0]
+	}
+
+	for _, i := range order {
+		answer := p.answers[i]
+		round := Round{Answer: answer}
+		if p.Options > 0 {
+			round.Options = Options(answer, p.pool, p.Options, p.config.Distractors)
+		}
+		p.Rounds = append(p.Rounds, round)
+	}
+}
+
+// Len returns the number of planned rounds
+func (p *Plan) Len() int {
+	return len(p.Rounds)
+}
+
+// Short reports whether the pool could not fill the requested rounds or options
+func (p *Plan) Short() bool {
+	return len(p.Rounds) < p.Wanted || p.Options < p.config.Options
+}
+
+// Notice explains to the player how the game was shortened, or returns ""
+func (p *Plan) Notice() string {
+	switch {
+	case len(p.Rounds) < p.Wanted && p.Options < p.config.Options:
+		return fmt.Sprintf(lang.X("rounds.short_both", "Only %d countries available: playing %d rounds with %d options."), len(p.pool), len(p.Rounds), p.Options)
+	case len(p.Rounds) < p.Wanted:
+		return fmt.Sprintf(lang.X("rounds.short_rounds", "Only %d countries available: playing %d rounds."), len(p.answers), len(p.Rounds))
+	case p.Options < p.config.Options:
+		return fmt.Sprintf(lang.X("rounds.short_options", "Only %d countries available: showing %d options."), len(p.pool), p.Options)
+	}
+	return ""
+}
+
+// Options returns n shuffled choices for answer: the answer itself, the
+// preferred distractors and random countries from pool to make up the rest.
+// Fewer than n are returned when the pool runs out.
+func Options(answer models.Country, pool []models.Country, n int, distractors DistractorFunc) []models.Country {
+	options := []models.Country{answer}
+	used := map[string]bool{answer.CCA2: true}
+	add := func(candidates []models.Country) {
+		for _, candidate := range candidates {
+			if len(options) == n {
+				return
+			}
+			if !used[candidate.CCA2] {
+				used[candidate.CCA2] = true
+				options = append(options, candidate)
+			}
+		}
+	}
+
+	if distractors != nil {
+		add(distractors(answer, pool, n-1))
+	}
+	random := make([]models.Country, len(pool))
+	for i, j := range rand.Perm(len(pool)) {
+		random[i] = pool[j]
+	}
+	add(random)
+
+	rand.Shuffle(len(options), func(i, j int) {
+		options[i], options[j] = options[j], options[i]
+	})
+	return options
+}
+
+// ErrorText returns a player facing message for errors from New
+func ErrorText(err error) string {
+	if errors.Is(err, ErrPoolTooSmall) {
+		return lang.X("rounds.pool_too_small", "This selection has too few countries for multiple choice. Pick a larger region.")
+	}
+	return lang.X("rounds.empty_pool", "No countries are available for this selection.")
+}
@@ -0,0 +1,159 @@
+package rounds
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"flagged-it/internal/data/models"
+)
+
+func testPool(n int) []models.Country {
+	pool := make([]models.Country, n)
+	for i := range pool {
+		pool[i] = models.Country{CCA2: fmt.Sprintf("C%d", i)}
+	}
+	return pool
+}
+
+// finishes fails the test if f doesn't return promptly, as a planner stuck
+// looking for a new country would
+func finishes(t *testing.T, f func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("did not finish; the planner loops on this pool")
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		pool        int
+		rounds      int
+		options     int
+		err         error
+		wantRounds  int
+		wantOptions int
+		short       bool
+	}{
+		{name: "empty pool", pool: 0, rounds: 10, err: ErrEmptyPool},
+		{name: "empty pool with options", pool: 0, rounds: 10, options: 4, err: ErrEmptyPool},
+		{name: "one country without options", pool: 1, rounds: 10, wantRounds: 1, short: true},
+		{name: "one country with options", pool: 1, rounds: 10, options: 4, err: ErrPoolTooSmall},
+		{name: "three countries", pool: 3, rounds: 10, options: 4, wantRounds: 3, wantOptions: 3, short: true},
+		{name: "fewer than rounds", pool: 7, rounds: 10, options: 4, wantRounds: 7, wantOptions: 4, short: true},
+		{name: "all countries", pool: 7, rounds: 0, options: 2, wantRounds: 7, wantOptions: 2},
+		{name: "enough countries", pool: 30, rounds: 10, options: 4, wantRounds: 10, wantOptions: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var plan *Plan
+			var err error
+			finishes(t, func() {
+				plan, err = New(testPool(tt.pool), Config{Rounds: tt.rounds, Options: tt.options})
+			})
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if plan.Len() != tt.wantRounds {
+				t.Errorf("planned %d rounds, want %d", plan.Len(), tt.wantRounds)
+			}
+			if plan.Short() != tt.short || (plan.Notice() != "") != tt.short {
+				t.Errorf("Short() = %v, Notice() = %q, want short = %v", plan.Short(), plan.Notice(), tt.short)
+			}
+			seen := make(map[string]bool)
+			for _, round := range plan.Rounds {
+				if seen[round.Answer.CCA2] {
+					t.Errorf("%s is asked twice in one pass", round.Answer.CCA2)
+				}
+				seen[round.Answer.CCA2] = true
+				if len(round.Options) != tt.wantOptions {
+					t.Errorf("round has %d options, want %d", len(round.Options), tt.wantOptions)
+				}
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		pool, n, want int
+	}{
+		{pool: 0, n: 4, want: 1},
+		{pool: 1, n: 4, want: 1},
+		{pool: 3, n: 4, want: 3},
+		{pool: 3, n: 2, want: 2},
+		{pool: 30, n: 4, want: 4},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d of %d", tt.n, tt.pool), func(t *testing.T) {
+			answer := models.Country{CCA2: "C0"}
+			// Distractors that repeat themselves and the answer must not
+			// crowd out or duplicate options
+			distractors := func(answer models.Country, pool []models.Country, n int) []models.Country {
+				return append([]models.Country{answer}, append(pool, pool...)...)
+			}
+			var options []models.Country
+			finishes(t, func() {
+				options = Options(answer, testPool(tt.pool), tt.n, distractors)
+			})
+			if len(options) != tt.want {
+				t.Fatalf("got %d options, want %d", len(options), tt.want)
+			}
+			seen := make(map[string]bool)
+			hasAnswer := false
+			for _, option := range options {
+				if seen[option.CCA2] {
+					t.Errorf("%s is offered twice", option.CCA2)
+				}
+				seen[option.CCA2] = true
+				hasAnswer = hasAnswer || option.CCA2 == answer.CCA2
+			}
+			if !hasAnswer {
+				t.Error("the answer is missing from the options")
+			}
+		})
+	}
+}
+
+func TestExtend(t *testing.T) {
+	for _, size := range []int{1, 2, 3, 7} {
+		t.Run(fmt.Sprintf("pool of %d", size), func(t *testing.T) {
+			plan, err := New(testPool(size), Config{Rounds: 10})
+			if err != nil {
+				t.Fatal(err)
+			}
+			finishes(t, func() {
+				for i := 0; i < 200; i++ {
+					plan.Extend()
+				}
+			})
+			if want := min(size, 10) + size*200; plan.Len() != want {
+				t.Errorf("planned %d rounds after extending, want %d", plan.Len(), want)
+			}
+			if size < 2 {
+				// A single country has to repeat
+				return
+			}
+			for i := 1; i < plan.Len(); i++ {
+				if plan.Rounds[i].Answer.CCA2 == plan.Rounds[i-1].Answer.CCA2 {
+					t.Fatalf("round %d repeats the previous answer %s", i, plan.Rounds[i].Answer.CCA2)
+				}
+			}
+		})
+	}
+}
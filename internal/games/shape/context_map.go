@@ -0,0 +1,203 @@
+package shape
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"flagged-it/internal/data"
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/ui/rasterizer"
+	"flagged-it/internal/utils"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	// contextMapHeight is the height of the map shown after each answer
+	contextMapHeight = 220
+	// contextMargin is how far around the answer, relative to its size,
+	// the map reaches
+	contextMargin = 0.5
+)
+
+var (
+	answerColor = color.RGBA{59, 130, 246, 255} // blue-500
+	guessColor  = color.RGBA{239, 68, 68, 255}  // red-500
+	// Neighbours are a translucent mid grey so they read on both themes
+	neighbourFill    = color.RGBA{64, 64, 64, 64}
+	neighbourOutline = color.RGBA{128, 128, 128, 255}
+)
+
+// setupContextMap builds the panel that shows the answer in its surroundings
+func (g *Game) setupContextMap() *fyne.Container {
+	g.contextLegend = widget.NewLabel("")
+	g.contextLegend.Wrapping = fyne.TextWrapWord
+	g.contextMap = container.NewMax()
+	g.contextPanel = container.NewBorder(nil, g.contextLegend, nil, nil, g.contextMap)
+	g.contextPanel.Hide()
+	return g.contextPanel
+}
+
+// hideContextMap clears the panel for the next country
+func (g *Game) hideContextMap() {
+	g.wrongGuesses = nil
+	g.contextMap.RemoveAll()
+	g.contextPanel.Hide()
+}
+
+// noteWrongGuess remembers which country a wrong guess named, so the map can
+// show it next to the answer
+func (g *Game) noteWrongGuess(guess string) {
+	for _, country := range g.countries {
+		if country.CCA3 != g.currentCountry.CCA3 && utils.MatchCountry(guess, country, utils.MatchAll) {
+			g.wrongGuesses = append(g.wrongGuesses, country)
+			return
+		}
+	}
+}
+
+// showContextMap draws the answer filled in blue among its outlined
+// neighbours, with any wrongly guessed countries in red
+func (g *Game) showContextMap() {
+	layers := g.contextLayers()
+	if len(layers) == 0 {
+		return
+	}
+
+	raster := canvas.NewRaster(func(w, h int) image.Image {
+		return rasterizer.RenderLayers(layers, rasterizer.Options{
+			Width:     w,
+			Height:    h,
+			Fit:       0.95,
+			AntiAlias: true,
+		})
+	})
+	raster.SetMinSize(fyne.NewSize(0, contextMapHeight))
+
+	g.contextMap.RemoveAll()
+	g.contextMap.Add(raster)
+	g.contextLegend.SetText(g.contextLegendText())
+	g.contextPanel.Show()
+}
+
+// contextLayers loads the answer, its context and the wrong guesses and
+// projects them together. The frame fits the answer and whatever lies near
+// it, so far-off islands and overseas territories don't shrink the map.
+func (g *Game) contextLayers() []rasterizer.Layer {
+	if len(g.currentCoords) == 0 {
+		return nil
+	}
+
+	answerMain := mainPolygon(g.currentCoords)
+	centre := [][][][]float64{g.currentCoords[answerMain]}
+	var guesses [][][][][]float64
+	for _, country := range g.wrongGuesses {
+		if coords := g.loadCoordinates(country); len(coords) > 0 {
+			guesses = append(guesses, coords)
+			centre = append(centre, coords[mainPolygon(coords)])
+		}
+	}
+	proj := newProjection(centre)
+
+	// Anything within half the answer's size of its main polygon is nearby
+	minX, maxX, minY, maxY := boundsOf(projectWith(proj, [][][][]float64{g.currentCoords[answerMain]}))
+	margin := math.Max(maxX-minX, maxY-minY) * contextMargin
+	near := func(polygon [][][]float64) bool {
+		pMinX, pMaxX, pMinY, pMaxY := boundsOf([][][][]float64{polygon})
+		return pMinX <= maxX+margin && pMaxX >= minX-margin && pMinY <= maxY+margin && pMaxY >= minY-margin
+	}
+
+	// Each polygon is its own layer so it can be in focus or not
+	var layers []rasterizer.Layer
+	add := func(coords [][][][]float64, style rasterizer.Layer, focus func(i int, polygon [][][]float64) bool) {
+		for i, polygon := range projectWith(proj, coords) {
+			layer := style
+			layer.Shape = rasterizer.NewShape([][][][]float64{polygon})
+			layer.Focus = focus(i, polygon)
+			layers = append(layers, layer)
+		}
+	}
+	nearOnly := func(_ int, polygon [][][]float64) bool { return near(polygon) }
+
+	neighbourStyle := rasterizer.Layer{Fill: neighbourFill, Outline: neighbourOutline, StrokeWidth: 1}
+	for _, country := range g.contextCountries() {
+		add(g.loadCoordinates(country), neighbourStyle, nearOnly)
+	}
+	add(g.currentCoords, rasterizer.Layer{Fill: answerColor}, nearOnly)
+	for _, coords := range guesses {
+		// A wrong guess is always in view, however far away it is
+		guessMain := mainPolygon(coords)
+		add(coords, rasterizer.Layer{Fill: guessColor}, func(i int, polygon [][][]float64) bool {
+			return i == guessMain || near(polygon)
+		})
+	}
+	return layers
+}
+
+// mainPolygon returns the index of the polygon with the largest outer ring
+func mainPolygon(coords [][][][]float64) int {
+	best, bestArea := 0, -1.0
+	for i, polygon := range coords {
+		if len(polygon) == 0 {
+			continue
+		}
+		area := 0.0
+		ring := polygon[0]
+		for j := range ring {
+			p1, p2 := ring[j], ring[(j+1)%len(ring)]
+			area += p1[0]*p2[1] - p2[0]*p1[1]
+		}
+		if math.Abs(area) > bestArea {
+			best, bestArea = i, math.Abs(area)
+		}
+	}
+	return best
+}
+
+// contextCountries are the countries sharing a border with the answer.
+// Island countries have none, so the rest of their subregion is shown instead.
+func (g *Game) contextCountries() []models.Country {
+	byCode := make(map[string]models.Country, len(g.countries))
+	for _, country := range g.countries {
+		byCode[country.CCA3] = country
+	}
+
+	var context []models.Country
+	for _, code := range data.LoadBorders()[g.currentCountry.CCA3] {
+		if country, ok := byCode[code]; ok {
+			context = append(context, country)
+		}
+	}
+	if len(context) > 0 {
+		return context
+	}
+	for _, country := range g.countries {
+		if country.Subregion == g.currentCountry.Subregion && country.CCA3 != g.currentCountry.CCA3 {
+			context = append(context, country)
+		}
+	}
+	return context
+}
+
+// loadCoordinates returns a country's outline, or nil if it has no geo data
+func (g *Game) loadCoordinates(country models.Country) [][][][]float64 {
+	geoData, err := data.LoadGeoData(country.CCA3)
+	if err != nil || len(geoData.Features) == 0 {
+		return nil
+	}
+	return geoData.Features[0].Geometry.Polygons()
+}
+
+func (g *Game) contextLegendText() string {
+	text := fmt.Sprintf(lang.X("game.shape.map_answer", "Blue: %s, outlined: its neighbours"), g.currentCountry.Name.Common)
+	for _, country := range g.wrongGuesses {
+		text += "\n" + fmt.Sprintf(lang.X("game.shape.map_guess", "Red: your guess, %s"), country.Name.Common)
+	}
+	return text
+}
@@ -0,0 +1,115 @@
+package shape
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+
+	"flagged-it/internal/ui/rasterizer"
+
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+// normalizedArea is the share of the canvas every shape covers when sizes
+// are normalized
+const normalizedArea = 0.3
+
+// outlineWidth is the stroke width of outline-only shapes, in pixels
+const outlineWidth = 3
+
+// disguises are the difficulty options that make a silhouette harder to
+// recognise from orientation or size alone
+type disguises struct {
+	rotate    bool
+	mirror    bool
+	normalize bool
+	outline   bool
+}
+
+// style is how one round's silhouette is drawn
+type style struct {
+	rotate    float64
+	mirror    bool
+	normalize bool
+	outline   bool
+}
+
+// newDisguiseSelector offers the difficulty options as check boxes
+func (g *Game) newDisguiseSelector() *widget.CheckGroup {
+	labels := []string{
+		lang.X("game.shape.rotate", "Rotate"),
+		lang.X("game.shape.mirror", "Mirror"),
+		lang.X("game.shape.normalize", "Same size"),
+		lang.X("game.shape.outline", "Outline only"),
+	}
+	selector := widget.NewCheckGroup(labels, func(selected []string) {
+		chosen := make(map[string]bool)
+		for _, label := range selected {
+			chosen[label] = true
+		}
+		g.disguises = disguises{
+			rotate:    chosen[labels[0]],
+			mirror:    chosen[labels[1]],
+			normalize: chosen[labels[2]],
+			outline:   chosen[labels[3]],
+		}
+	})
+	selector.Horizontal = true
+	return selector
+}
+
+// styleFor picks the style of the round at idx. It only depends on the run's
+// seed, so shapes rendered ahead of time come out the same.
+func (g *Game) styleFor(idx int) style {
+	r := rand.New(rand.NewSource(g.styleSeed + int64(idx)))
+	st := style{normalize: g.disguises.normalize, outline: g.disguises.outline}
+	if g.disguises.rotate {
+		st.rotate = r.Float64() * 2 * math.Pi
+	}
+	if g.disguises.mirror {
+		// Mirror only half the time, or players would just learn mirror images
+		st.mirror = r.Intn(2) == 0
+	}
+	return st
+}
+
+// cacheID keys a shape's rendered image by country and style
+func (st style) cacheID(id string) string {
+	if st == (style{}) {
+		return id
+	}
+	return fmt.Sprintf("%s/%.4f/%t/%t/%t", id, st.rotate, st.mirror, st.normalize, st.outline)
+}
+
+// apply sets the renderer options for the style
+func (st style) apply(opts rasterizer.Options) rasterizer.Options {
+	opts.Rotate = st.rotate
+	opts.Mirror = st.mirror
+	if st.normalize {
+		opts.Area = normalizedArea
+	}
+	if st.outline {
+		opts.Outline = outlineWidth
+	}
+	return opts
+}
+
+// variant describes the chosen difficulty options for the scoreboard
+func (d disguises) variant() string {
+	var parts []string
+	if d.rotate {
+		parts = append(parts, "rotated")
+	}
+	if d.mirror {
+		parts = append(parts, "mirrored")
+	}
+	if d.normalize {
+		parts = append(parts, "same size")
+	}
+	if d.outline {
+		parts = append(parts, "outline")
+	}
+	return strings.Join(parts, " ")
+}
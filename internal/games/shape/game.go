@@ -5,7 +5,6 @@ import (
 	"image"
 	"image/color"
 	"math"
-	"math/rand"
 	"sort"
 	"strings"
 	"sync"
@@ -13,7 +12,10 @@ import (
 
 	"flagged-it/internal/data"
 	"flagged-it/internal/data/models"
+	"flagged-it/internal/games/rounds"
+	"flagged-it/internal/games/survival"
 	"flagged-it/internal/ui/components"
+	"flagged-it/internal/ui/rasterizer"
 	"flagged-it/internal/utils"
 
 	"fyne.io/fyne/v2"
@@ -27,8 +29,14 @@ import (
 const (
 	canvasWidth  = 1000.0
 	canvasHeight = 700.0
+	// revealDelay leaves time to look at the answer on the map
+	revealDelay = 3 * time.Second
 )
 
+// shapeCache keeps rendered shapes across games; a few sizes of a region's
+// countries fit comfortably
+var shapeCache = rasterizer.NewCache(64)
+
 type Game struct {
 	content         *fyne.Container
 	backFunc        func()
@@ -49,7 +57,36 @@ type Game struct {
 	currentCoords   [][][][]float64
 	coordCache      map[int][][][][]float64
 	cacheMutex      sync.RWMutex
+	renderWidth     int // last canvas size in pixels, guarded by cacheMutex
+	renderHeight    int
 	gameProgress    *components.GameProgress
+	mode            components.PlayMode
+	blitzAnswered   int
+	countdown       *components.Countdown
+	blitzBar        *fyne.Container
+	blitzScoreLabel *widget.Label
+	session         *survival.Session
+	survivalBar     *fyne.Container
+	hintBar         *fyne.Container
+	hintBtn         *components.Button
+	hintLabel       *widget.Label
+	hintFlag        *canvas.Image
+	hintLevel       hintLevel
+	hintsUsed       int
+	attempts        int     // wrong guesses for the current country
+	answered        int     // classic rounds finished
+	points          float64 // classic score after hint deductions
+	startTime       time.Time
+	contextPanel    *fyne.Container
+	contextMap      *fyne.Container
+	contextLegend   *widget.Label
+	wrongGuesses    []models.Country // countries named by wrong guesses this round
+	results         []roundResult    // finished classic rounds, for the summary
+	replaying       bool             // replaying mistakes, which isn't scored
+	run             int              // counts started runs, to spot stale timers
+	disguises       disguises
+	styleSeed       int64 // seeds each round's rotation and mirroring
+	style           style // how the current shape is drawn
 }
 
 func NewGame(backFunc func()) *Game {
@@ -62,7 +99,7 @@ func NewGame(backFunc func()) *Game {
 }
 
 func (g *Game) setupUI() {
-	topBar := components.NewTopBar(lang.X("game.shape.title", "Guess by Shape"), g.backFunc, g.Reset)
+	topBar := components.NewTopBar(lang.X("game.shape.title", "Guess by Shape"), g.goBack, g.Reset)
 
 	g.setupSelectionView()
 	g.setupGameView()
@@ -83,7 +120,16 @@ func (g *Game) setupSelectionView() {
 		availableRegions,
 		g.startRegionGame,
 	)
-	g.selectionView = regionSelector.GetContainer()
+	modes := append([]components.PlayMode{components.ClassicMode}, components.BlitzModes()...)
+	modes = append(modes, components.SurvivalMode)
+	modeSelector := components.NewPlayModeSelector(modes, func(mode components.PlayMode) {
+		g.mode = mode
+	})
+	g.selectionView = container.NewVBox(
+		modeSelector,
+		g.newDisguiseSelector(),
+		regionSelector.GetContainer(),
+	)
 }
 
 func (g *Game) getAvailableRegions() []string {
@@ -139,15 +185,29 @@ func (g *Game) setupGameView() {
 		ShowProgressBar: true,
 	})
 
+	// Countdown and running score for blitz mode
+	g.countdown = components.NewCountdown()
+	g.countdown.OnExpired = g.endBlitz
+	g.blitzScoreLabel = widget.NewLabel("")
+	g.blitzBar = container.NewBorder(nil, nil, g.blitzScoreLabel, g.countdown.GetContainer())
+	g.blitzBar.Hide()
+
+	// Lives and run length for survival mode
+	g.survivalBar = container.NewMax()
+	g.survivalBar.Hide()
+
 	topSection := container.NewVBox(
 		g.gameProgress.GetContainer(),
+		g.blitzBar,
+		g.survivalBar,
 		g.progressLabel,
 		guessContainer,
+		g.setupHints(),
 		g.resultLabel,
 	)
 
 	g.gameView = container.NewBorder(
-		topSection, nil, nil, nil,
+		topSection, g.setupContextMap(), nil, nil,
 		shapeWindow,
 	)
 }
@@ -160,53 +220,35 @@ func (g *Game) drawShape(coords [][][][]float64) {
 		return
 	}
 
-	g.drawMainShape(coords)
-}
-
-func (g *Game) calculatePolygonArea(ring [][]float64) float64 {
-	if len(ring) < 3 {
-		return 0
-	}
-	area := 0.0
-	for i := 0; i < len(ring); i++ {
-		j := (i + 1) % len(ring)
-		area += ring[i][0] * ring[j][1]
-		area -= ring[j][0] * ring[i][1]
-	}
-	return math.Abs(area) / 2.0
+	marker, _ := g.capitalMarker(coords)
+	g.drawMainShape(g.currentCountry.CCA3, rasterizer.NewShape(projectShape(coords)), marker)
 }
 
-func (g *Game) drawMainShape(coords [][][][]float64) {
-	minX, maxX, minY, maxY := g.calculateBounds(coords)
-	if minX == maxX || minY == maxY {
+// drawMainShape draws a projected shape scaled to fit the canvas, with an
+// optional marker dot in projected coordinates. Rendered images are cached
+// per country, size and theme so resizes are cheap.
+func (g *Game) drawMainShape(id string, shape *rasterizer.Shape, marker []float64) {
+	if shape.Empty() {
 		return
 	}
 
+	dark := fyne.CurrentApp().Settings().ThemeVariant() == theme.VariantDark
+	st := g.style
 	raster := canvas.NewRaster(func(w, h int) image.Image {
-		img := image.NewRGBA(image.Rect(0, 0, w, h))
-
-		for y := 0; y < h; y++ {
-			for x := 0; x < w; x++ {
-				img.Set(x, y, color.RGBA{0, 0, 0, 0})
-			}
-		}
-
-		scaleX := float64(w) / (maxX - minX)
-		scaleY := float64(h) / (maxY - minY)
-		scale := math.Min(scaleX, scaleY) * 0.9
-
-		shapeWidth := (maxX - minX) * scale
-		shapeHeight := (maxY - minY) * scale
-		offsetX := (float64(w) - shapeWidth) / 2
-		offsetY := (float64(h) - shapeHeight) / 2
-
-		for _, polygon := range coords {
-			if len(polygon) > 0 {
-				g.fillPolygon(img, polygon[0], minX, minY, scale, offsetX, offsetY, float64(h))
-			}
+		g.cacheMutex.Lock()
+		g.renderWidth, g.renderHeight = w, h
+		g.cacheMutex.Unlock()
+		img := renderShape(id, shape, st, w, h, dark)
+		if marker == nil {
+			return img
 		}
-
-		return img
+		// Mark a copy so the cached image stays clean
+		marked := image.NewRGBA(img.Rect)
+		copy(marked.Pix, img.Pix)
+		x, y := shape.PixelPoint(marker[0], marker[1], st.apply(shapeOptions(w, h, dark)))
+		radius := math.Max(4, float64(min(w, h))/80)
+		rasterizer.DrawDot(marked, x, y, radius, color.RGBA{239, 68, 68, 255})
+		return marked
 	})
 
 	canvasSize := g.shapeCanvas.Size()
@@ -218,93 +260,92 @@ func (g *Game) drawMainShape(coords [][][][]float64) {
 	g.shapeCanvas.Add(raster)
 }
 
-func (g *Game) calculateBounds(coords [][][][]float64) (minX, maxX, minY, maxY float64) {
-	first := true
-	for _, polygon := range coords {
-		for _, ring := range polygon {
-			for _, point := range ring {
-				if len(point) < 2 {
-					continue
-				}
-				lon, lat := point[0], point[1]
-				if first {
-					minX, maxX, minY, maxY = lon, lon, lat, lat
-					first = false
-				} else {
-					if lon < minX {
-						minX = lon
-					}
-					if lon > maxX {
-						maxX = lon
-					}
-					if lat < minY {
-						minY = lat
-					}
-					if lat > maxY {
-						maxY = lat
-					}
-				}
-			}
-		}
+// renderShape returns the cached image of a country shape, drawing it if needed
+func renderShape(id string, shape *rasterizer.Shape, st style, w, h int, dark bool) *image.RGBA {
+	key := rasterizer.Key{ID: st.cacheID(id), Width: w, Height: h, Dark: dark}
+	return shapeCache.GetOrRender(key, func() *image.RGBA {
+		return rasterizer.Render(shape, st.apply(shapeOptions(w, h, dark)))
+	})
+}
+
+// shapeOptions are the render settings for the main shape
+func shapeOptions(w, h int, dark bool) rasterizer.Options {
+	fillColor := color.RGBA{0, 0, 0, 255}
+	if dark {
+		fillColor = color.RGBA{255, 255, 255, 255}
+	}
+	return rasterizer.Options{
+		Width:     w,
+		Height:    h,
+		Color:     fillColor,
+		Fit:       0.9,
+		AntiAlias: true,
 	}
-	return
 }
 
-func (g *Game) fillPolygon(img *image.RGBA, ring [][]float64, minX, minY, scale, offsetX, offsetY, height float64) {
-	if len(ring) < 3 {
+// prerenderNext draws the upcoming shape in the background at the last
+// canvas size, so it shows instantly when the player moves on
+func (g *Game) prerenderNext() {
+	// Survival picks the next country only once the answer is in
+	if g.session != nil {
 		return
 	}
-
-	points := make([][2]int, len(ring))
-	for i, point := range ring {
-		if len(point) < 2 {
-			continue
-		}
-		x := int((point[0]-minX)*scale + offsetX)
-		y := int(height - (point[1]-minY)*scale - offsetY)
-		points[i] = [2]int{x, y}
+	idx := g.currentIndex
+	if idx >= len(g.regionCountries) {
+		return
 	}
+	country := g.regionCountries[idx]
+	dark := fyne.CurrentApp().Settings().ThemeVariant() == theme.VariantDark
 
-	var fillColor color.RGBA
-	if fyne.CurrentApp().Settings().ThemeVariant() == theme.VariantDark {
-		fillColor = color.RGBA{255, 255, 255, 255}
-	} else {
-		fillColor = color.RGBA{0, 0, 0, 255}
+	g.cacheMutex.RLock()
+	coords, exists := g.coordCache[idx]
+	w, h := g.renderWidth, g.renderHeight
+	g.cacheMutex.RUnlock()
+	if w == 0 || h == 0 {
+		return
 	}
 
-	bounds := img.Bounds()
-	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
-		intersections := []int{}
-
-		for i := 0; i < len(points); i++ {
-			j := (i + 1) % len(points)
-			p1, p2 := points[i], points[j]
-
-			if (p1[1] <= y && y < p2[1]) || (p2[1] <= y && y < p1[1]) {
-				x := p1[0] + (y-p1[1])*(p2[0]-p1[0])/(p2[1]-p1[1])
-				intersections = append(intersections, x)
+	go func() {
+		if !exists {
+			geoData, err := data.LoadGeoData(country.CCA3)
+			if err != nil || len(geoData.Features) == 0 {
+				return
 			}
+			coords = geoData.Features[0].Geometry.Polygons()
 		}
+		if len(coords) > 0 {
+			renderShape(country.CCA3, rasterizer.NewShape(projectShape(coords)), g.styleFor(idx), w, h, dark)
+		}
+	}()
+}
 
-		sort.Ints(intersections)
-		for i := 0; i < len(intersections); i += 2 {
-			if i+1 < len(intersections) {
-				for x := intersections[i]; x <= intersections[i+1]; x++ {
-					if x >= bounds.Min.X && x < bounds.Max.X {
-						img.Set(x, y, fillColor)
-					}
-				}
+func (g *Game) nextCountry() {
+	// Survival picks countries by difficulty rather than in order
+	if g.session != nil {
+		country := g.session.Next()
+		for i := range g.regionCountries {
+			if g.regionCountries[i].CCA2 == country.CCA2 {
+				g.currentIndex = i
+				break
 			}
 		}
 	}
-}
 
-func (g *Game) nextCountry() {
+	// Blitz runs can outlast the region; go round again
+	if g.mode.IsBlitz() && g.currentIndex >= len(g.regionCountries) {
+		g.currentIndex = 0
+	}
+
 	// Check if we've gone through all countries
 	if g.currentIndex >= len(g.regionCountries) {
 		// Game complete
 		if g.total > 0 {
-			g.resultLabel.SetText(fmt.Sprintf(lang.X("game.shape.complete", "Game Complete! Final Score: %d/%d (%.1f%%)"), g.score, g.total, float64(g.score)/float64(g.total)*100))
+			g.guessEntry.Disable()
+			g.hintBtn.Disable()
+			if !g.replaying {
+				g.saveClassicScore()
+			}
+			g.showSummary()
 		}
 		return
 	}
@@ -312,6 +353,7 @@ func (g *Game) nextCountry() {
 	// Get the next country (all countries are pre-validated to have geo data)
 	idx := g.currentIndex
 	g.currentCountry = g.regionCountries[idx]
+	g.style = g.styleFor(idx)
 	g.currentIndex++
 
 	// Get coordinates from cache or load them
@@ -322,7 +364,7 @@ func (g *Game) nextCountry() {
 	if !exists {
 		geoData, err := data.LoadGeoData(g.currentCountry.CCA3)
 		if err == nil && len(geoData.Features) > 0 {
-			coords = g.parseCoordinates(geoData.Features[0].Geometry)
+			coords = geoData.Features[0].Geometry.Polygons()
 		}
 		g.cacheMutex.Lock()
 		g.coordCache[idx] = coords
@@ -330,110 +372,104 @@ func (g *Game) nextCountry() {
 	}
 
 	// Display the shape
+	g.resetHints()
+	g.hideContextMap()
 	g.drawShape(coords)
+	g.prerenderNext()
 	g.guessEntry.SetText("")
 	g.resultLabel.SetText("")
 	g.updateProgress()
 }
 
-func (g *Game) parseCoordinates(geom models.Geometry) [][][][]float64 {
-	var coords [][][][]float64
-
-	switch geom.Type {
-	case "Polygon":
-		if polygonCoords := g.parsePolygon(geom.Coordinates); len(polygonCoords) > 0 {
-			coords = [][][][]float64{polygonCoords}
-		}
-	case "MultiPolygon":
-		if multiCoords, ok := geom.Coordinates.([]interface{}); ok {
-			for _, poly := range multiCoords {
-				if polygonCoords := g.parsePolygon(poly); len(polygonCoords) > 0 {
-					coords = append(coords, polygonCoords)
-				}
-			}
-		}
-	}
-
-	return coords
-}
-
-func (g *Game) parsePolygon(coordsInterface interface{}) [][][]float64 {
-	var polygonCoords [][][]float64
-
-	if coordsArray, ok := coordsInterface.([]interface{}); ok {
-		for _, ringInterface := range coordsArray {
-			if ring := g.parseRing(ringInterface); len(ring) > 0 {
-				polygonCoords = append(polygonCoords, ring)
-			}
-		}
-	}
-
-	return polygonCoords
-}
-
-func (g *Game) parseRing(ringInterface interface{}) [][]float64 {
-	var ring [][]float64
-
-	if ringArray, ok := ringInterface.([]interface{}); ok {
-		for _, pointInterface := range ringArray {
-			if pointArray, ok := pointInterface.([]interface{}); ok && len(pointArray) >= 2 {
-				if lon, ok1 := pointArray[0].(float64); ok1 {
-					if lat, ok2 := pointArray[1].(float64); ok2 {
-						ring = append(ring, []float64{lon, lat})
-					}
-				}
-			}
-		}
-	}
-
-	return ring
-}
-
 func (g *Game) startRegionGame(region string) {
 	g.selectedRegion = region
 	g.regionCountries = []models.Country{}
 	g.coordCache = make(map[int][][][][]float64)
 
 	// Filter countries by region
+	var pool []models.Country
 	for _, country := range g.countries {
 		if region == "World" || country.Region == region {
-			g.regionCountries = append(g.regionCountries, country)
+			pool = append(pool, country)
 		}
 	}
 
-	// Pre-filter to only include countries with valid geo data
-	validCountries := []models.Country{}
-	for _, country := range g.regionCountries {
-		if country.CCA3 != "" {
-			geoData, err := data.LoadGeoData(country.CCA3)
-			if err == nil && len(geoData.Features) > 0 {
-				coords := g.parseCoordinates(geoData.Features[0].Geometry)
-				if len(coords) > 0 {
-					validCountries = append(validCountries, country)
-				}
-			}
-		}
+	// Plan every country with valid geo data once, in random order
+	plan, err := rounds.New(pool, rounds.Config{Eligible: g.hasShape})
+	if err != nil {
+		g.mainContent.RemoveAll()
+		g.mainContent.Add(g.gameView)
+		g.mainContent.Refresh()
+		g.guessEntry.Disable()
+		g.resultLabel.SetText(rounds.ErrorText(err))
+		return
 	}
-	g.regionCountries = validCountries
-
-	// Shuffle the valid countries
-	rand.Shuffle(len(g.regionCountries), func(i, j int) {
-		g.regionCountries[i], g.regionCountries[j] = g.regionCountries[j], g.regionCountries[i]
-	})
+	for _, round := range plan.Rounds {
+		g.regionCountries = append(g.regionCountries, round.Answer)
+	}
+	g.replaying = false
+	g.startRun()
+}
 
+// startRun plays g.regionCountries in order in the selected mode
+func (g *Game) startRun() {
+	g.run++
+	g.styleSeed = time.Now().UnixNano()
 	g.score = 0
 	g.total = len(g.regionCountries) // Now we know the exact total upfront
 	g.currentIndex = 0
 	g.coordCache = make(map[int][][][][]float64)
 
+	g.blitzAnswered = 0
+	g.answered = 0
+	g.points = 0
+	g.hintsUsed = 0
+	g.startTime = time.Now()
+	g.countdown.Stop()
+	g.session = nil
+	g.guessEntry.Enable()
+	g.gameProgress.GetContainer().Hide()
+	g.blitzBar.Hide()
+	g.survivalBar.Hide()
+	g.hintBar.Hide()
+
+	switch {
+	case g.mode.IsBlitz():
+		g.blitzBar.Show()
+		g.blitzScoreLabel.SetText(fmt.Sprintf(lang.X("game.blitz.score", "Correct: %d"), 0))
+		g.countdown.Start(g.mode.TimeLimit)
+	case g.mode.Survival:
+		g.session = survival.NewSession("shape", g.selectedRegion, g.regionCountries)
+		g.survivalBar.RemoveAll()
+		g.survivalBar.Add(g.session.GetContainer())
+		g.survivalBar.Show()
+	default:
+		g.gameProgress.GetContainer().Show()
+		g.hintBar.Show()
+	}
+
 	g.mainContent.RemoveAll()
 	g.mainContent.Add(g.gameView)
 	g.mainContent.Refresh()
 
+	g.results = nil
+
 	go g.preprocessCoordinates()
 	g.nextCountry()
 }
 
+// hasShape reports whether a country has geo data that can be drawn
+func (g *Game) hasShape(country models.Country) bool {
+	if country.CCA3 == "" {
+		return false
+	}
+	geoData, err := data.LoadGeoData(country.CCA3)
+	if err != nil || len(geoData.Features) == 0 {
+		return false
+	}
+	return len(geoData.Features[0].Geometry.Polygons()) > 0
+}
+
 func (g *Game) preprocessCoordinates() {
 	for i := range g.regionCountries {
 		g.cacheMutex.RLock()
@@ -443,7 +479,7 @@ func (g *Game) preprocessCoordinates() {
 		if !exists && g.regionCountries[i].CCA3 != "" {
 			geoData, err := data.LoadGeoData(g.regionCountries[i].CCA3)
 			if err == nil && len(geoData.Features) > 0 {
-				coords := g.parseCoordinates(geoData.Features[0].Geometry)
+				coords := geoData.Features[0].Geometry.Polygons()
 				g.cacheMutex.Lock()
 				g.coordCache[i] = coords
 				g.cacheMutex.Unlock()
@@ -458,37 +494,193 @@ func (g *Game) checkGuess(guess string) {
 		return
 	}
 
-	if utils.MatchCountry(guess, g.currentCountry, utils.MatchAll) {
+	if g.mode.IsBlitz() {
+		g.checkBlitzGuess(guess)
+		return
+	}
+	if g.session != nil {
+		g.checkSurvivalGuess(guess)
+		return
+	}
+
+	correct := utils.MatchCountry(guess, g.currentCountry, utils.MatchAll)
+	if correct {
 		g.score++
+		g.points += g.roundPoints()
 		g.resultLabel.SetText(fmt.Sprintf(lang.X("game.shape.correct", "Correct! It's %s"), g.currentCountry.Name.Common))
 	} else {
+		g.attempts++
+		g.noteWrongGuess(guess)
+		if left := maxAttempts - g.attempts; left > 0 {
+			// Let the player try again, possibly with another hint
+			g.guessEntry.SetText("")
+			g.resultLabel.SetText(fmt.Sprintf(lang.X("game.shape.attempts_left", "Not quite! %d attempts left"), left))
+			return
+		}
 		g.resultLabel.SetText(fmt.Sprintf(lang.X("game.shape.wrong", "Wrong! It's %s"), g.currentCountry.Name.Common))
 	}
 
+	g.results = append(g.results, roundResult{
+		country: g.currentCountry,
+		coords:  g.currentCoords,
+		answer:  guess,
+		correct: correct,
+		hints:   int(g.hintLevel),
+	})
+	g.answered++
+	g.guessEntry.Disable()
+	g.hintBtn.Disable()
+	g.updateProgress()
+	g.showContextMap()
+
+	run := g.run
+	time.AfterFunc(revealDelay, func() {
+		fyne.Do(func() {
+			// Ignore the timer if the player started a new game meanwhile
+			if g.run == run {
+				g.guessEntry.Enable()
+				g.nextCountry()
+			}
+		})
+	})
+}
+
+// checkBlitzGuess scores a blitz answer and moves straight to the next shape;
+// wrong answers cost time instead of a pause
+func (g *Game) checkBlitzGuess(guess string) {
+	if !g.countdown.Running() {
+		return
+	}
+
+	g.blitzAnswered++
+	previous := g.currentCountry.Name.Common
+	correct := utils.MatchCountry(guess, g.currentCountry, utils.MatchAll)
+	if correct {
+		g.score++
+	} else {
+		g.countdown.Penalize(components.BlitzPenalty)
+	}
+	g.blitzScoreLabel.SetText(fmt.Sprintf(lang.X("game.blitz.score", "Correct: %d"), g.score))
+	if !g.countdown.Running() {
+		// The penalty ran the clock out and ended the game
+		return
+	}
+
+	g.nextCountry()
+	if correct {
+		g.resultLabel.SetText(fmt.Sprintf(lang.X("game.shape.correct", "Correct! It's %s"), previous))
+	} else {
+		g.resultLabel.SetText(fmt.Sprintf(lang.X("game.blitz.penalty", "Wrong! It was %s (-%ds)"), previous, int(components.BlitzPenalty.Seconds())))
+	}
+}
+
+// checkSurvivalGuess reveals the answer and either continues the run or ends it
+func (g *Game) checkSurvivalGuess(guess string) {
+	isCorrect := utils.MatchCountry(guess, g.currentCountry, utils.MatchAll)
+	if isCorrect {
+		g.resultLabel.SetText(fmt.Sprintf(lang.X("game.shape.correct", "Correct! It's %s"), g.currentCountry.Name.Common))
+	} else {
+		g.resultLabel.SetText(fmt.Sprintf(lang.X("game.shape.wrong", "Wrong! It's %s"), g.currentCountry.Name.Common))
+		g.noteWrongGuess(guess)
+	}
 	g.guessEntry.Disable()
+	g.showContextMap()
 
-	time.AfterFunc(2*time.Second, func() {
+	session := g.session
+	if session.Answer(isCorrect) {
+		g.resultLabel.SetText(g.resultLabel.Text + "\n" + session.GameOverText())
+		return
+	}
+
+	time.AfterFunc(revealDelay, func() {
 		fyne.Do(func() {
-			g.guessEntry.Enable()
-			g.nextCountry()
+			// Ignore the timer if the player started a new game meanwhile
+			if g.session == session {
+				g.guessEntry.Enable()
+				g.nextCountry()
+			}
 		})
 	})
 }
 
+// endBlitz is called when the countdown runs out
+func (g *Game) endBlitz() {
+	g.guessEntry.Disable()
+
+	percent := 0.0
+	if g.blitzAnswered > 0 {
+		percent = float64(g.score) / float64(g.blitzAnswered) * 100
+	}
+	utils.SaveScore(utils.ScoreEntry{
+		GameMode: "shape_blitz",
+		Score:    g.score,
+		Total:    g.blitzAnswered,
+		Percent:  percent,
+		Duration: int(g.countdown.Elapsed().Seconds()),
+		Region:   g.selectedRegion,
+		Variant:  strings.TrimSpace(fmt.Sprintf("%ds %s", int(g.mode.TimeLimit.Seconds()), g.disguises.variant())),
+	})
+
+	g.resultLabel.SetText(fmt.Sprintf(lang.X("game.blitz.complete_shape", "Time's up! You got %d of %d shapes right. It was %s."), g.score, g.blitzAnswered, g.currentCountry.Name.Common))
+}
+
+// goBack stops a running blitz countdown before leaving the game
+func (g *Game) goBack() {
+	g.countdown.Stop()
+	g.backFunc()
+}
+
 func (g *Game) GetContent() *fyne.Container {
 	return g.content
 }
 
 func (g *Game) updateProgress() {
-	// Update game progress component with current position, total, and score
-	g.gameProgress.UpdateProgress(g.currentIndex, g.total, g.score)
+	// Update game progress component with current position, total, and the
+	// score so far after hint deductions
+	g.gameProgress.UpdateProgressWithPercent(g.currentIndex, g.total, g.answeredPercent())
 	
-	// Update region-specific progress label
+	// Update region-specific progress label; endless modes have no fixed count
 	translatedRegion := utils.TranslateRegion(g.selectedRegion)
+	if g.mode != components.ClassicMode {
+		g.progressLabel.SetText(translatedRegion)
+		return
+	}
 	g.progressLabel.SetText(fmt.Sprintf(lang.X("game.shape.progress", "%s: Country %d/%d"), translatedRegion, g.currentIndex, g.total))
 }
 
+// percent is the final classic score, counting hint deductions
+func (g *Game) percent() float64 {
+	if g.total == 0 {
+		return 0
+	}
+	return g.points / float64(g.total) * 100
+}
+
+// answeredPercent is the classic score over the rounds finished so far
+func (g *Game) answeredPercent() float64 {
+	if g.answered == 0 {
+		return 0
+	}
+	return g.points / float64(g.answered) * 100
+}
+
+// saveClassicScore records a finished classic game with the hints used
+func (g *Game) saveClassicScore() {
+	utils.SaveScore(utils.ScoreEntry{
+		GameMode: "shape",
+		Score:    g.score,
+		Total:    g.total,
+		Percent:  g.percent(),
+		Duration: int(time.Since(g.startTime).Seconds()),
+		Region:   g.selectedRegion,
+		Variant:  g.disguises.variant(),
+		Hints:    g.hintsUsed,
+	})
+}
+
 func (g *Game) showSelection() {
+	g.run++
+	g.countdown.Stop()
 	g.mainContent.RemoveAll()
 	g.mainContent.Add(g.selectionView)
 	g.mainContent.Refresh()
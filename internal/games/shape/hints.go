@@ -0,0 +1,132 @@
+package shape
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"flagged-it/internal/ui/components"
+	"flagged-it/internal/utils"
+	"flagged-it/pkg/assets"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+// maxAttempts is how many guesses a classic round allows before the answer
+// is revealed
+const maxAttempts = 3
+
+// hintPenalty is the share of a round's point each hint costs
+const hintPenalty = 0.2
+
+type hintLevel int
+
+// Hints are revealed in this order, from vague to giving it away
+const (
+	hintNone hintLevel = iota
+	hintRegion
+	hintFirstLetter
+	hintCapital
+	hintFlag
+)
+
+// setupHints builds the hint button, the text hints and the flag hint shown
+// below the guess entry in classic mode
+func (g *Game) setupHints() *fyne.Container {
+	g.hintBtn = components.NewButton(g.hintButtonText(), g.showNextHint)
+
+	g.hintLabel = widget.NewLabel("")
+	g.hintLabel.Wrapping = fyne.TextWrapWord
+
+	g.hintFlag = canvas.NewImageFromResource(nil)
+	g.hintFlag.FillMode = canvas.ImageFillContain
+	g.hintFlag.SetMinSize(fyne.NewSize(60, 40))
+	g.hintFlag.Hide()
+
+	g.hintBar = container.NewBorder(nil, nil, g.hintBtn, g.hintFlag, g.hintLabel)
+	return g.hintBar
+}
+
+// resetHints clears the hints and attempts for a new country
+func (g *Game) resetHints() {
+	g.hintLevel = hintNone
+	g.attempts = 0
+	g.hintLabel.SetText("")
+	g.hintFlag.Hide()
+	g.hintBtn.SetText(g.hintButtonText())
+	g.hintBtn.Enable()
+}
+
+func (g *Game) hintButtonText() string {
+	return fmt.Sprintf(lang.X("game.shape.hint", "Hint (-%d%%)"), int(math.Round(hintPenalty*100)))
+}
+
+// showNextHint reveals the next hint on the ladder
+func (g *Game) showNextHint() {
+	if g.hintLevel >= hintFlag || g.guessEntry.Disabled() {
+		return
+	}
+	g.hintLevel++
+	g.hintsUsed++
+
+	switch g.hintLevel {
+	case hintCapital:
+		// Redraw with the capital marked on the shape
+		g.drawShape(g.currentCoords)
+	case hintFlag:
+		if flagResource, err := assets.LoadFlagResource(g.currentCountry.CCA2); err == nil {
+			g.hintFlag.Resource = flagResource
+			g.hintFlag.Refresh()
+			g.hintFlag.Show()
+		}
+		g.hintBtn.Disable()
+	}
+	g.hintLabel.SetText(g.hintText())
+}
+
+// hintText lists the text hints revealed so far
+func (g *Game) hintText() string {
+	var lines []string
+	if g.hintLevel >= hintRegion {
+		region := utils.TranslateRegion(g.currentCountry.Region)
+		if g.currentCountry.Subregion != "" {
+			region += " · " + utils.TranslateRegion(g.currentCountry.Subregion)
+		}
+		lines = append(lines, fmt.Sprintf(lang.X("game.shape.hint_region", "Region: %s"), region))
+	}
+	if g.hintLevel >= hintFirstLetter {
+		first, _ := utf8.DecodeRuneInString(g.currentCountry.Name.Common)
+		lines = append(lines, fmt.Sprintf(lang.X("game.flag.hint_starts_with", "Starts with %s"), string(first)))
+	}
+	if g.hintLevel >= hintCapital {
+		lines = append(lines, lang.X("game.shape.hint_capital", "The red dot marks the capital"))
+	}
+	if g.hintLevel >= hintFlag {
+		lines = append(lines, lang.X("game.shape.hint_flag", "This is its flag"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// roundPoints is what a correct answer is worth with the hints used so far
+func (g *Game) roundPoints() float64 {
+	return math.Max(0, 1-hintPenalty*float64(g.hintLevel))
+}
+
+// capitalMarker returns the capital in the shape's projected coordinates,
+// once the capital hint has been revealed
+func (g *Game) capitalMarker(coords [][][][]float64) ([]float64, bool) {
+	latlng := g.currentCountry.CapitalInfo.Latlng
+	if g.hintLevel < hintCapital || len(latlng) < 2 {
+		return nil, false
+	}
+	x, y, ok := newProjection(coords).project(latlng[1], latlng[0])
+	if !ok {
+		return nil, false
+	}
+	return []float64{x, y}, true
+}
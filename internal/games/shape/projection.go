@@ -0,0 +1,111 @@
+package shape
+
+import "math"
+
+// projection is a Lambert azimuthal equal-area projection centred on one
+// country, so large or polar countries such as Russia, Canada and Greenland
+// keep their real proportions
+type projection struct {
+	lon0, sinLat0, cosLat0 float64
+	// unwrap shifts western longitudes by 360° for countries crossing ±180°
+	unwrap bool
+}
+
+// newProjection centres a projection on the extent of coords. Countries
+// spanning more than half the globe only do so because they straddle the
+// antimeridian (Fiji, Russia's Chukotka, the Aleutians), so their western
+// longitudes are unwrapped and rings cut at ±180° join up again.
+func newProjection(coords [][][][]float64) projection {
+	p := projection{}
+	minLon, maxLon, minLat, maxLat := boundsOf(coords)
+	if maxLon-minLon > 180 {
+		p.unwrap = true
+		minLon, maxLon = math.Inf(1), math.Inf(-1)
+		for _, polygon := range coords {
+			for _, ring := range polygon {
+				for _, point := range ring {
+					lon := p.unwrapLon(point[0])
+					minLon = math.Min(minLon, lon)
+					maxLon = math.Max(maxLon, lon)
+				}
+			}
+		}
+	}
+
+	p.lon0 = (minLon + maxLon) / 2 * math.Pi / 180
+	lat0 := (minLat + maxLat) / 2 * math.Pi / 180
+	p.sinLat0, p.cosLat0 = math.Sin(lat0), math.Cos(lat0)
+	return p
+}
+
+func (p projection) unwrapLon(lon float64) float64 {
+	if p.unwrap && lon < 0 {
+		return lon + 360
+	}
+	return lon
+}
+
+// project maps lon/lat in degrees to planar x/y with y pointing north. It
+// fails only for the antipode of the centre, which can't be projected.
+func (p projection) project(lon, lat float64) (x, y float64, ok bool) {
+	lambda := p.unwrapLon(lon) * math.Pi / 180
+	phi := lat * math.Pi / 180
+	sinLat, cosLat := math.Sin(phi), math.Cos(phi)
+	cosDLon := math.Cos(lambda - p.lon0)
+
+	denominator := 1 + p.sinLat0*sinLat + p.cosLat0*cosLat*cosDLon
+	if denominator <= 1e-9 {
+		return 0, 0, false
+	}
+	k := math.Sqrt(2 / denominator)
+	x = k * cosLat * math.Sin(lambda-p.lon0)
+	y = k * (p.cosLat0*sinLat - p.sinLat0*cosLat*cosDLon)
+	return x, y, true
+}
+
+// projectShape turns GeoJSON lon/lat coordinates into planar x/y with y
+// pointing north, using a projection centred on the shape itself
+func projectShape(coords [][][][]float64) [][][][]float64 {
+	return projectWith(newProjection(coords), coords)
+}
+
+func projectWith(p projection, coords [][][][]float64) [][][][]float64 {
+	projected := make([][][][]float64, len(coords))
+	for i, polygon := range coords {
+		projected[i] = make([][][]float64, len(polygon))
+		for j, ring := range polygon {
+			projected[i][j] = make([][]float64, 0, len(ring))
+			for _, point := range ring {
+				if x, y, ok := p.project(point[0], point[1]); ok {
+					projected[i][j] = append(projected[i][j], []float64{x, y})
+				}
+			}
+		}
+	}
+	return projected
+}
+
+// boundsOf returns the extent of all points in coords
+func boundsOf(coords [][][][]float64) (minX, maxX, minY, maxY float64) {
+	first := true
+	for _, polygon := range coords {
+		for _, ring := range polygon {
+			for _, point := range ring {
+				if len(point) < 2 {
+					continue
+				}
+				x, y := point[0], point[1]
+				if first {
+					minX, maxX, minY, maxY = x, x, y, y
+					first = false
+					continue
+				}
+				minX = math.Min(minX, x)
+				maxX = math.Max(maxX, x)
+				minY = math.Min(minY, y)
+				maxY = math.Max(maxY, y)
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,204 @@
+package shape
+
+import (
+	"encoding/json"
+	"image"
+	"image/color"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"flagged-it/internal/data"
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/ui/rasterizer"
+	"flagged-it/internal/ui/rasterizer/golden"
+)
+
+const goldenSize = 256
+
+func countryCoords(t *testing.T, cca3 string) [][][][]float64 {
+	t.Helper()
+	geoData, err := data.LoadGeoData(cca3)
+	if err != nil || len(geoData.Features) == 0 {
+		t.Fatalf("no outline for %s: %v", cca3, err)
+	}
+	return geoData.Features[0].Geometry.Polygons()
+}
+
+// fixtureCoords loads an outline from testdata, for countries the embedded
+// data has none for
+func fixtureCoords(t *testing.T, name string) [][][][]float64 {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var geoData models.GeoJSON
+	if err := json.Unmarshal(raw, &geoData); err != nil || len(geoData.Features) == 0 {
+		t.Fatalf("bad fixture %s: %v", name, err)
+	}
+	return geoData.Features[0].Geometry.Polygons()
+}
+
+// renderCountry draws coords the way the game draws the shape to guess
+func renderCountry(coords [][][][]float64) (*rasterizer.Shape, rasterizer.Options, *image.RGBA) {
+	shape := rasterizer.NewShape(projectShape(coords))
+	opts := shapeOptions(goldenSize, goldenSize, false)
+	return shape, opts, rasterizer.Render(shape, opts)
+}
+
+// renderZoomed draws coords framed on a small lon/lat box, to look at
+// details too small to see at full size
+func renderZoomed(coords [][][][]float64, minLon, minLat, maxLon, maxLat float64) (func(lon, lat float64) (float64, float64), *image.RGBA) {
+	p := newProjection(coords)
+	corner := func(lon, lat float64) []float64 {
+		x, y, _ := p.project(lon, lat)
+		return []float64{x, y}
+	}
+	frame := rasterizer.NewShape([][][][]float64{{{
+		corner(minLon, minLat), corner(maxLon, minLat), corner(maxLon, maxLat), corner(minLon, maxLat),
+	}}})
+	opts := rasterizer.Options{Width: goldenSize, Height: goldenSize, Fit: 1, AntiAlias: true}
+	img := rasterizer.RenderLayers([]rasterizer.Layer{
+		{Shape: frame, Focus: true},
+		{Shape: rasterizer.NewShape(projectWith(p, coords)), Fill: color.RGBA{0, 0, 0, 255}},
+	}, opts)
+
+	pixel := func(lon, lat float64) (float64, float64) {
+		x, y, _ := p.project(lon, lat)
+		return frame.PixelPoint(x, y, opts)
+	}
+	return pixel, img
+}
+
+// pixelAt maps lon/lat to the pixel it lands on in a full size render
+func pixelAt(coords [][][][]float64, shape *rasterizer.Shape, opts rasterizer.Options, lon, lat float64) (float64, float64) {
+	x, y, _ := newProjection(coords).project(lon, lat)
+	return shape.PixelPoint(x, y, opts)
+}
+
+func alphaAt(img *image.RGBA, x, y float64) uint8 {
+	return img.RGBAAt(int(x), int(y)).A
+}
+
+// Enclaves are holes in the surrounding country's outline and must stay
+// empty under the even-odd rule
+func TestRenderEnclaveHoles(t *testing.T) {
+	t.Run("south_africa_lesotho", func(t *testing.T) {
+		coords := countryCoords(t, "ZAF")
+		shape, opts, img := renderCountry(coords)
+
+		if x, y := pixelAt(coords, shape, opts, 28.25, -29.6); alphaAt(img, x, y) != 0 {
+			t.Errorf("Lesotho is filled in at (%.0f, %.0f)", x, y)
+		}
+		if x, y := pixelAt(coords, shape, opts, 24, -30); alphaAt(img, x, y) != 255 {
+			t.Errorf("South Africa's interior is empty at (%.0f, %.0f)", x, y)
+		}
+		golden.Compare(t, "south_africa_lesotho", img)
+	})
+
+	t.Run("italy", func(t *testing.T) {
+		coords := countryCoords(t, "ITA")
+		_, _, img := renderCountry(coords)
+		golden.Compare(t, "italy", img)
+	})
+
+	// Italy's outline has San Marino and the Vatican as holes; each is
+	// looked at on a frame a few times its own size
+	coords := countryCoords(t, "ITA")
+	enclaves := map[string][]float64{"italy_san_marino": {12.46, 43.94}, "italy_vatican": {12.45, 41.90}}
+	for name, near := range enclaves {
+		t.Run(name, func(t *testing.T) {
+			minLon, minLat, maxLon, maxLat, ok := holeNear(coords, near[0], near[1])
+			if !ok {
+				t.Fatal("the enclave is not a hole in Italy's outline")
+			}
+			padLon, padLat := maxLon-minLon, maxLat-minLat
+			pixel, img := renderZoomed(coords, minLon-padLon, minLat-padLat, maxLon+padLon, maxLat+padLat)
+
+			if x, y := pixel((minLon+maxLon)/2, (minLat+maxLat)/2); alphaAt(img, x, y) != 0 {
+				t.Errorf("the enclave is filled in at (%.0f, %.0f)", x, y)
+			}
+			if x, y := pixel(minLon-padLon/2, minLat-padLat/2); alphaAt(img, x, y) != 255 {
+				t.Errorf("Italy is empty around the enclave at (%.0f, %.0f)", x, y)
+			}
+			golden.Compare(t, name, img)
+		})
+	}
+}
+
+// holeNear returns the extent of the hole in coords closest to lon/lat
+func holeNear(coords [][][][]float64, lon, lat float64) (minLon, minLat, maxLon, maxLat float64, ok bool) {
+	best := math.Inf(1)
+	for _, polygon := range coords {
+		for _, hole := range polygon[1:] {
+			hMinLon, hMaxLon, hMinLat, hMaxLat := boundsOf([][][][]float64{{hole}})
+			d := math.Hypot((hMinLon+hMaxLon)/2-lon, (hMinLat+hMaxLat)/2-lat)
+			if d < best && d < 0.5 {
+				best = d
+				minLon, minLat, maxLon, maxLat, ok = hMinLon, hMinLat, hMaxLon, hMaxLat, true
+			}
+		}
+	}
+	return
+}
+
+// The equal-area projection keeps high latitude countries in proportion,
+// where plain longitude and latitude would stretch them sideways
+func TestRenderEqualAreaProjection(t *testing.T) {
+	t.Run("russia", func(t *testing.T) {
+		coords := countryCoords(t, "RUS")
+		if !newProjection(coords).unwrap {
+			t.Error("Chukotka across the antimeridian is not joined to the rest of Russia")
+		}
+		_, _, img := renderCountry(coords)
+		golden.Compare(t, "russia", img)
+	})
+
+	t.Run("greenland", func(t *testing.T) {
+		coords := countryCoords(t, "GRL")
+		// Greenland is about 2,650 km north to south and 1,050 km across
+		minX, maxX, minY, maxY := boundsOf(projectShape(coords))
+		if ratio := (maxY - minY) / (maxX - minX); ratio < 1.2 {
+			t.Errorf("Greenland is %.2f times as tall as wide, want it clearly taller", ratio)
+		}
+		_, _, img := renderCountry(coords)
+		golden.Compare(t, "greenland", img)
+	})
+}
+
+// Countries straddling ±180° are drawn in one piece rather than split
+// across both edges of the image
+func TestRenderAntimeridian(t *testing.T) {
+	tests := []struct {
+		name   string
+		coords func(t *testing.T) [][][][]float64
+	}{
+		{"fiji", func(t *testing.T) [][][][]float64 { return countryCoords(t, "FJI") }},
+		{"kiribati", func(t *testing.T) [][][][]float64 { return fixtureCoords(t, "kiribati") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coords := tt.coords(t)
+			p := newProjection(coords)
+			if !p.unwrap {
+				t.Fatal("western longitudes are not unwrapped")
+			}
+
+			// Centred on the plain longitude extent, the projection would sit
+			// half a world away and tear the islands apart
+			split := p
+			minLon, maxLon, _, _ := boundsOf(coords)
+			split.lon0 = (minLon + maxLon) / 2 * math.Pi / 180
+			joinedMinX, joinedMaxX, _, _ := boundsOf(projectWith(p, coords))
+			splitMinX, splitMaxX, _, _ := boundsOf(projectWith(split, coords))
+			if joinedMaxX-joinedMinX >= (splitMaxX-splitMinX)/2 {
+				t.Errorf("unwrapped width %.3f is not much narrower than the split width %.3f", joinedMaxX-joinedMinX, splitMaxX-splitMinX)
+			}
+
+			_, _, img := renderCountry(coords)
+			golden.Compare(t, tt.name, img)
+		})
+	}
+}
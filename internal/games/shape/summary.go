@@ -0,0 +1,106 @@
+package shape
+
+import (
+	"fmt"
+	"image"
+	"time"
+
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/ui/components"
+	"flagged-it/internal/ui/rasterizer"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// roundResult is one finished round of a classic run
+type roundResult struct {
+	country models.Country
+	coords  [][][][]float64
+	answer  string // the player's last guess
+	correct bool
+	hints   int
+}
+
+// showSummary replaces the game view with the results of the run: every
+// country's silhouette with the player's answer and the correct name
+func (g *Game) showSummary() {
+	title := widget.NewLabel(fmt.Sprintf(lang.X("game.shape.complete", "Game Complete! Final Score: %d/%d (%.0f%%)"), g.score, g.total, g.percent()))
+	title.TextStyle.Bold = true
+	title.Wrapping = fyne.TextWrapWord
+
+	elapsed := time.Since(g.startTime).Round(time.Second)
+	duration := widget.NewLabel(fmt.Sprintf(lang.X("game.shape.duration", "Time: %s"), elapsed))
+
+	mistakes := g.mistakes()
+	replayBtn := components.NewButton(fmt.Sprintf(lang.X("game.shape.replay_mistakes", "Replay mistakes (%d)"), len(mistakes)), func() {
+		g.replayMistakes(mistakes)
+	})
+	if len(mistakes) == 0 {
+		replayBtn.Disable()
+	}
+	regionBtn := components.NewButton(lang.X("game.shape.choose_again", "Choose region"), g.showSelection)
+
+	rows := container.NewVBox()
+	dark := fyne.CurrentApp().Settings().ThemeVariant() == theme.VariantDark
+	for _, result := range g.results {
+		rows.Add(summaryRow(result, dark))
+	}
+
+	header := container.NewVBox(title, duration, container.NewGridWithColumns(2, replayBtn, regionBtn))
+	summary := container.NewBorder(header, nil, nil, nil, container.NewVScroll(rows))
+
+	g.mainContent.RemoveAll()
+	g.mainContent.Add(summary)
+	g.mainContent.Refresh()
+}
+
+// summaryRow shows one round: thumbnail, the correct name and what was guessed
+func summaryRow(result roundResult, dark bool) fyne.CanvasObject {
+	shape := rasterizer.NewShape(projectShape(result.coords))
+	// Thumbnails are small enough to draw directly without the shape cache
+	thumbnail := canvas.NewRaster(func(w, h int) image.Image {
+		return rasterizer.Render(shape, shapeOptions(w, h, dark))
+	})
+	thumbnail.SetMinSize(fyne.NewSize(64, 48))
+
+	name := widget.NewLabel(result.country.Name.Common)
+	name.TextStyle.Bold = true
+
+	answer := fmt.Sprintf(lang.X("game.shape.your_answer", "Your answer: %s"), result.answer)
+	if result.hints > 0 {
+		answer += " · " + fmt.Sprintf(lang.X("scoreboard.hints", "%d hints"), result.hints)
+	}
+
+	bg := canvas.NewRectangle(components.WrongAnswerColor)
+	if result.correct {
+		bg.FillColor = components.CorrectAnswerColor
+	}
+
+	row := container.NewBorder(nil, nil, thumbnail, nil, container.NewVBox(name, widget.NewLabel(answer)))
+	return container.NewStack(bg, row)
+}
+
+// mistakes returns the countries the player missed in the last run
+func (g *Game) mistakes() []models.Country {
+	var missed []models.Country
+	for _, result := range g.results {
+		if !result.correct {
+			missed = append(missed, result.country)
+		}
+	}
+	return missed
+}
+
+// replayMistakes plays the missed countries again as practice; the replay
+// isn't saved to the scoreboard
+func (g *Game) replayMistakes(countries []models.Country) {
+	g.regionCountries = countries
+	g.coordCache = make(map[int][][][][]float64)
+	g.replaying = true
+	g.startRun()
+}
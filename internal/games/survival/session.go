@@ -0,0 +1,170 @@
+package survival
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/utils"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	// StartingLives is the number of wrong answers a run survives
+	StartingLives = 3
+	// baseWindow is how many of the best known countries the run starts with
+	baseWindow = 20
+	// windowStep widens the pool every levelLength correct answers
+	windowStep  = 10
+	levelLength = 3
+)
+
+// Session wraps an endless survival run for any game that asks about one
+// country at a time. Countries start with populous, well known ones and move
+// towards small island states as the run gets longer.
+type Session struct {
+	gameMode  string
+	region    string
+	pool      []models.Country // sorted from easiest to hardest
+	used      map[string]bool
+	lives     int
+	run       int
+	answers   int // correct and wrong
+	best      int
+	startTime time.Time
+	hud       *widget.Label
+}
+
+// NewSession starts a run for a game mode (e.g. "flag") over a country pool
+func NewSession(gameMode, region string, countries []models.Country) *Session {
+	pool := append([]models.Country(nil), countries...)
+	sort.SliceStable(pool, func(i, j int) bool {
+		return pool[i].Population > pool[j].Population
+	})
+
+	s := &Session{
+		gameMode:  gameMode + "_survival",
+		region:    region,
+		pool:      pool,
+		used:      make(map[string]bool),
+		lives:     StartingLives,
+		startTime: time.Now(),
+		hud:       widget.NewLabel(""),
+	}
+	if best := utils.GetBestScore(s.gameMode, region); best != nil {
+		s.best = best.Score
+	}
+	s.updateHUD()
+	return s
+}
+
+// Next picks the next country, drawing from a window of the difficulty
+// ordered pool that widens as the run grows. Returns nil for an empty pool.
+func (s *Session) Next() *models.Country {
+	if len(s.pool) == 0 {
+		return nil
+	}
+	if len(s.used) >= len(s.pool) {
+		s.used = make(map[string]bool)
+	}
+
+	hi := baseWindow + (s.run/levelLength)*windowStep
+	if hi > len(s.pool) {
+		hi = len(s.pool)
+	}
+	lo := hi - 2*baseWindow
+	if lo < 0 {
+		lo = 0
+	}
+
+	if country := s.pick(lo, hi); country != nil {
+		return country
+	}
+	// The window is exhausted; fall back to anything not yet asked
+	return s.pick(0, len(s.pool))
+}
+
+func (s *Session) pick(lo, hi int) *models.Country {
+	var candidates []int
+	for i := lo; i < hi; i++ {
+		if !s.used[s.pool[i].CCA2] {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	country := &s.pool[candidates[rand.Intn(len(candidates))]]
+	s.used[country.CCA2] = true
+	return country
+}
+
+// Answer records a guess and reports whether the run is over. The run is
+// saved to the scoreboard when the last life is lost.
+func (s *Session) Answer(correct bool) bool {
+	if s.Over() {
+		return true
+	}
+
+	s.answers++
+	if correct {
+		s.run++
+	} else {
+		s.lives--
+	}
+	if s.run > s.best {
+		s.best = s.run
+	}
+	s.updateHUD()
+
+	if !s.Over() {
+		return false
+	}
+
+	// The run length is the score; the percentage is the run's accuracy
+	utils.SaveScore(utils.ScoreEntry{
+		GameMode: s.gameMode,
+		Score:    s.run,
+		Total:    s.answers,
+		Percent:  float64(s.run) / float64(s.answers) * 100,
+		Duration: int(time.Since(s.startTime).Seconds()),
+		Region:   s.region,
+	})
+	return true
+}
+
+// Over reports whether the player has run out of lives
+func (s *Session) Over() bool {
+	return s.lives <= 0
+}
+
+// Run returns the number of correct answers so far
+func (s *Session) Run() int {
+	return s.run
+}
+
+// Best returns the longest run for this mode and region, including the current one
+func (s *Session) Best() int {
+	return s.best
+}
+
+// GameOverText describes the finished run
+func (s *Session) GameOverText() string {
+	return fmt.Sprintf(lang.X("survival.game_over", "Out of lives! Your run: %d (best: %d)"), s.run, s.best)
+}
+
+func (s *Session) updateHUD() {
+	lives := strings.Repeat("❤️", s.lives) + strings.Repeat("🖤", StartingLives-s.lives)
+	s.hud.SetText(fmt.Sprintf(lang.X("survival.status", "%s  Run: %d  Best: %d"), lives, s.run, s.best))
+}
+
+// GetContainer returns the lives and run display
+func (s *Session) GetContainer() fyne.CanvasObject {
+	return s.hud
+}
@@ -0,0 +1,60 @@
+package components
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+// AnswerList is the numbered progress display of the "name them all" games.
+// Each answer shows once found; until then only its number does. Revealing
+// the list shows the missing answers in red.
+type AnswerList struct {
+	list     *widget.List
+	revealed bool
+}
+
+// NewAnswerList creates a list of count() answers; item returns the answer
+// at id and whether the player has found it
+func NewAnswerList(count func() int, item func(id int) (answer string, found bool)) *AnswerList {
+	l := &AnswerList{}
+	l.list = widget.NewList(
+		count,
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			answer, found := item(id)
+			label.Importance = widget.MediumImportance
+			switch {
+			case found:
+				label.SetText(fmt.Sprintf(lang.X("game.list.country_item", "%d. %s"), id+1, answer))
+			case l.revealed:
+				label.Importance = widget.DangerImportance
+				label.SetText(fmt.Sprintf(lang.X("game.list.country_item", "%d. %s"), id+1, answer))
+			default:
+				label.SetText(fmt.Sprintf(lang.X("game.list.country_unknown", "%d. ?"), id+1))
+			}
+		},
+	)
+	return l
+}
+
+// SetRevealed shows or hides the answers not found yet
+func (l *AnswerList) SetRevealed(revealed bool) {
+	l.revealed = revealed
+	l.list.Refresh()
+}
+
+// Refresh redraws the list after answers are found
+func (l *AnswerList) Refresh() {
+	l.list.Refresh()
+}
+
+// GetContainer returns the list widget
+func (l *AnswerList) GetContainer() fyne.CanvasObject {
+	return l.list
+}
@@ -0,0 +1,65 @@
+package components
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+var (
+	// CorrectAnswerColor highlights the right option after a guess
+	CorrectAnswerColor = color.RGBA{30, 180, 80, 255}
+	// WrongAnswerColor highlights the wrong options after a guess
+	WrongAnswerColor = color.RGBA{255, 99, 71, 255}
+)
+
+// ColoredButton is an answer button whose background can be recoloured
+// to reveal whether the option was right or wrong
+type ColoredButton struct {
+	widget.BaseWidget
+	Button *widget.Button
+	rect   *canvas.Rectangle
+}
+
+// NewColoredButton creates a low importance answer button with a transparent background
+func NewColoredButton(label string, tapped func()) *ColoredButton {
+	btn := widget.NewButtonWithIcon(label, nil, tapped)
+	btn.Importance = widget.LowImportance
+
+	c := &ColoredButton{
+		Button: btn,
+		rect:   canvas.NewRectangle(color.Transparent),
+	}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+func (c *ColoredButton) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewStack(
+		c.rect,
+		c.Button,
+	))
+}
+
+// SetBgColor changes the background color behind the button
+func (c *ColoredButton) SetBgColor(col color.Color) {
+	c.rect.FillColor = col
+	c.Refresh()
+}
+
+// Disable disables the wrapped button
+func (c *ColoredButton) Disable() {
+	c.Button.Disable()
+}
+
+func (c *ColoredButton) Cursor() desktop.Cursor {
+	return desktop.PointerCursor
+}
+
+func (c *ColoredButton) Tapped(e *fyne.PointEvent) {
+	c.Button.Tapped(e)
+}
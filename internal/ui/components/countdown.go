@@ -0,0 +1,157 @@
+package components
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"flagged-it/internal/utils"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/lang"
+)
+
+const countdownTick = 100 * time.Millisecond
+
+var (
+	// BlitzDurations are the time limits offered by time attack modes
+	BlitzDurations = []time.Duration{60 * time.Second, 120 * time.Second}
+	// BlitzPenalty is deducted from the clock for each wrong answer
+	BlitzPenalty = 5 * time.Second
+)
+
+// Countdown displays a count down timer that pauses while the app is hidden
+type Countdown struct {
+	label     *canvas.Text
+	remaining time.Duration
+	elapsed   time.Duration
+	lastTick  time.Time
+	paused    bool // the app was hidden at the last tick
+	stop      chan struct{}
+	running   bool
+	OnExpired func()
+}
+
+// NewCountdown creates a stopped countdown
+func NewCountdown() *Countdown {
+	c := &Countdown{
+		label: canvas.NewText("", color.RGBA{107, 114, 128, 255}), // gray-500
+	}
+	c.label.TextSize = 20
+	c.label.TextStyle.Bold = true
+	return c
+}
+
+// Start (re)starts the countdown from limit
+func (c *Countdown) Start(limit time.Duration) {
+	c.Stop()
+	c.remaining = limit
+	c.elapsed = 0
+	c.lastTick = time.Now()
+	c.paused = false
+	c.running = true
+	c.stop = make(chan struct{})
+	c.updateLabel()
+
+	ticker := time.NewTicker(countdownTick)
+	stop := c.stop
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				fyne.Do(c.tick)
+			}
+		}
+	}()
+}
+
+// tick runs on the UI goroutine so state needs no locking
+func (c *Countdown) tick() {
+	if !c.running {
+		return
+	}
+
+	now := time.Now()
+	delta := now.Sub(c.lastTick)
+	c.lastTick = now
+	if utils.IsAppHidden() {
+		c.paused = true
+		return
+	}
+	// Browsers throttle timers in background tabs, so the first tick back
+	// may span hidden time; it isn't charged. Any other lag is real time
+	// and counts in full.
+	if c.paused {
+		c.paused = false
+		return
+	}
+
+	c.remaining -= delta
+	c.elapsed += delta
+	c.updateLabel()
+	c.expireIfDone()
+}
+
+// Penalize removes d from the remaining time
+func (c *Countdown) Penalize(d time.Duration) {
+	if !c.running {
+		return
+	}
+	c.remaining -= d
+	c.updateLabel()
+	c.expireIfDone()
+}
+
+// expireIfDone stops the clock and fires OnExpired once no time is left
+func (c *Countdown) expireIfDone() {
+	if c.remaining > 0 {
+		return
+	}
+	c.Stop()
+	if c.OnExpired != nil {
+		c.OnExpired()
+	}
+}
+
+// Stop halts the countdown without firing OnExpired
+func (c *Countdown) Stop() {
+	if c.stop != nil {
+		close(c.stop)
+		c.stop = nil
+	}
+	c.running = false
+}
+
+// Running reports whether the countdown is active
+func (c *Countdown) Running() bool {
+	return c.running
+}
+
+// Elapsed returns the time actually played, excluding pauses
+func (c *Countdown) Elapsed() time.Duration {
+	return c.elapsed
+}
+
+func (c *Countdown) updateLabel() {
+	remaining := c.remaining
+	if remaining < 0 {
+		remaining = 0
+	}
+	seconds := int((remaining + time.Second - 1) / time.Second)
+	c.label.Text = fmt.Sprintf(lang.X("countdown.remaining", "⏱ %d:%02d"), seconds/60, seconds%60)
+	if remaining <= 10*time.Second {
+		c.label.Color = color.RGBA{239, 68, 68, 255} // red-500
+	} else {
+		c.label.Color = color.RGBA{107, 114, 128, 255} // gray-500
+	}
+	c.label.Refresh()
+}
+
+// GetContainer returns the countdown display
+func (c *Countdown) GetContainer() fyne.CanvasObject {
+	return c.label
+}
@@ -0,0 +1,59 @@
+package components
+
+import (
+	"testing"
+	"time"
+
+	"flagged-it/internal/utils"
+)
+
+// runningCountdown is a countdown mid-game, without the ticker goroutine
+func runningCountdown(remaining time.Duration) *Countdown {
+	c := NewCountdown()
+	c.remaining = remaining
+	c.lastTick = time.Now()
+	c.running = true
+	return c
+}
+
+func TestCountdownChargesLaggingTicksInFull(t *testing.T) {
+	c := runningCountdown(10 * time.Second)
+	c.lastTick = time.Now().Add(-time.Second)
+	c.tick()
+	if c.remaining > 9*time.Second || c.elapsed < time.Second {
+		t.Errorf("after a 1s tick remaining = %v, elapsed = %v", c.remaining, c.elapsed)
+	}
+}
+
+func TestCountdownSkipsHiddenTime(t *testing.T) {
+	c := runningCountdown(10 * time.Second)
+	utils.SetAppHidden(true)
+	c.lastTick = time.Now().Add(-time.Second)
+	c.tick()
+	utils.SetAppHidden(false)
+	// The first tick back spans the throttled hidden time
+	c.lastTick = time.Now().Add(-30 * time.Second)
+	c.tick()
+	if c.remaining != 10*time.Second || c.elapsed != 0 {
+		t.Errorf("hidden time was charged: remaining = %v, elapsed = %v", c.remaining, c.elapsed)
+	}
+}
+
+func TestCountdownPenaltyExpires(t *testing.T) {
+	c := runningCountdown(3 * time.Second)
+	expired := 0
+	c.OnExpired = func() { expired++ }
+
+	c.Penalize(2 * time.Second)
+	if expired != 0 || !c.Running() {
+		t.Fatal("expired with time left")
+	}
+	c.Penalize(2 * time.Second)
+	if expired != 1 || c.Running() {
+		t.Errorf("penalty past zero: expired %d times, running = %v", expired, c.Running())
+	}
+	c.Penalize(2 * time.Second)
+	if expired != 1 {
+		t.Errorf("expired again after stopping")
+	}
+}
@@ -0,0 +1,16 @@
+package components
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// NewFactText renders a country fact written in markdown. Facts follow one
+// emphasis convention: **bold** for the names and numbers a fact hinges on,
+// *italics* for foreign words and titles. Both are optional, so plain text
+// renders as is.
+func NewFactText(markdown string) *widget.RichText {
+	text := widget.NewRichTextFromMarkdown(markdown)
+	text.Wrapping = fyne.TextWrapWord
+	return text
+}
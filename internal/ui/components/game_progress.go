@@ -66,6 +66,16 @@ func NewGameProgress(config GameProgressConfig) *GameProgress {
 
 // UpdateProgress updates all progress indicators
 func (gp *GameProgress) UpdateProgress(current, total, score int) {
+	percentage := 0.0
+	if total > 0 {
+		percentage = float64(score) / float64(total) * 100
+	}
+	gp.UpdateProgressWithPercent(current, total, percentage)
+}
+
+// UpdateProgressWithPercent updates all progress indicators with a precomputed
+// percentage, for games where the score is not one point per round
+func (gp *GameProgress) UpdateProgressWithPercent(current, total int, percentage float64) {
 	// Update round label
 	if gp.roundLabel != nil {
 		if total > 0 {
@@ -90,7 +100,6 @@ func (gp *GameProgress) UpdateProgress(current, total, score int) {
 
 	// Update percentage with color coding (based on score performance)
 	if gp.percentLabel != nil && total > 0 {
-		percentage := float64(score) / float64(total) * 100
 		gp.percentLabel.Text = fmt.Sprintf("%.0f%%", percentage)
 		
 		// Color based on performance
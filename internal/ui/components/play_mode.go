@@ -0,0 +1,66 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+)
+
+// PlayMode describes how a game session is played
+type PlayMode struct {
+	TimeLimit time.Duration // blitz when non-zero
+	Survival  bool
+}
+
+var (
+	// ClassicMode plays a fixed number of rounds
+	ClassicMode = PlayMode{}
+	// SurvivalMode plays until the player runs out of lives
+	SurvivalMode = PlayMode{Survival: true}
+)
+
+// BlitzModes returns a blitz mode for each of the BlitzDurations
+func BlitzModes() []PlayMode {
+	modes := make([]PlayMode, len(BlitzDurations))
+	for i, d := range BlitzDurations {
+		modes[i] = PlayMode{TimeLimit: d}
+	}
+	return modes
+}
+
+// IsBlitz reports whether the mode is played against the clock
+func (m PlayMode) IsBlitz() bool {
+	return m.TimeLimit > 0
+}
+
+// Label returns the translated mode name
+func (m PlayMode) Label() string {
+	switch {
+	case m.Survival:
+		return lang.X("mode.survival", "Survival")
+	case m.IsBlitz():
+		return fmt.Sprintf(lang.X("mode.blitz", "Blitz %ds"), int(m.TimeLimit.Seconds()))
+	}
+	return lang.X("mode.classic", "Classic")
+}
+
+// NewPlayModeSelector creates a radio group for choosing between modes,
+// with the first mode selected
+func NewPlayModeSelector(modes []PlayMode, onChange func(mode PlayMode)) *widget.RadioGroup {
+	options := make([]string, len(modes))
+	byLabel := make(map[string]PlayMode, len(modes))
+	for i, mode := range modes {
+		options[i] = mode.Label()
+		byLabel[options[i]] = mode
+	}
+
+	radio := widget.NewRadioGroup(options, func(selected string) {
+		onChange(byLabel[selected])
+	})
+	radio.Horizontal = true
+	radio.Required = true
+	radio.SetSelected(options[0])
+	return radio
+}
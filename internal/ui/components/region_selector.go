@@ -2,24 +2,50 @@ package components
 
 import (
 	"flagged-it/internal/utils"
+	"fmt"
 	"sort"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
 	"fyne.io/fyne/v2/widget"
 )
 
+// RegionSelectorConfig holds the configuration for a region selector
+type RegionSelectorConfig struct {
+	Title            string
+	Description      string
+	Regions          []string
+	Subregions       map[string][]string // optional: subregions listed under each region
+	RoundCounts      []int               // optional: round counts to pick from, 0 means all
+	OnRoundCount     func(int)
+	OnRegionSelected func(string)
+}
+
 type RegionSelector struct {
 	container *fyne.Container
+	buttons   map[string]*Button // by region and subregion
 }
 
 func NewRegionSelector(title, description string, regions []string, onRegionSelected func(string)) *RegionSelector {
-	titleLabel := widget.NewLabel(title)
+	return NewRegionSelectorWithConfig(RegionSelectorConfig{
+		Title:            title,
+		Description:      description,
+		Regions:          regions,
+		OnRegionSelected: onRegionSelected,
+	})
+}
+
+// NewRegionSelectorWithConfig creates a region selector that can also offer
+// subregions and a round count picker
+func NewRegionSelectorWithConfig(config RegionSelectorConfig) *RegionSelector {
+	titleLabel := widget.NewLabel(config.Title)
 	titleLabel.TextStyle.Bold = true
 
-	descLabel := widget.NewLabel(description)
+	descLabel := widget.NewLabel(config.Description)
 	descLabel.Wrapping = fyne.TextWrapWord
 
+	regions := config.Regions
 	// Sort regions with World first
 	sort.Slice(regions, func(i, j int) bool {
 		if regions[i] == "World" {
@@ -36,24 +62,88 @@ func NewRegionSelector(title, description string, regions []string, onRegionSele
 	if utils.IsMobile() {
 		columns = 1
 	}
+	buttons := make(map[string]*Button)
 	buttonGrid := container.NewGridWithColumns(columns)
 	for _, region := range regions {
 		region := region
 		translatedRegion := utils.TranslateRegion(region)
-		buttonGrid.Add(NewButton(translatedRegion, func() {
-			onRegionSelected(region)
-		}))
+		buttons[region] = NewButton(translatedRegion, func() {
+			config.OnRegionSelected(region)
+		})
+		buttonGrid.Add(buttons[region])
+	}
+
+	content := container.NewVBox(titleLabel, descLabel)
+	if len(config.RoundCounts) > 0 {
+		content.Add(NewRoundCountPicker(config.RoundCounts, config.OnRoundCount))
+	}
+	content.Add(buttonGrid)
+
+	// Subregions are folded away per region to keep the list short
+	if len(config.Subregions) > 0 {
+		accordion := widget.NewAccordion()
+		for _, region := range regions {
+			subregions := config.Subregions[region]
+			if len(subregions) == 0 {
+				continue
+			}
+			sort.Strings(subregions)
+			subGrid := container.NewGridWithColumns(columns)
+			for _, subregion := range subregions {
+				subregion := subregion
+				buttons[subregion] = NewButton(utils.TranslateRegion(subregion), func() {
+					config.OnRegionSelected(subregion)
+				})
+				subGrid.Add(buttons[subregion])
+			}
+			title := fmt.Sprintf(lang.X("region.subregions_of", "%s: subregions"), utils.TranslateRegion(region))
+			accordion.Append(widget.NewAccordionItem(title, subGrid))
+		}
+		content.Add(accordion)
 	}
 
 	return &RegionSelector{
-		container: container.NewVBox(
-			titleLabel,
-			descLabel,
-			buttonGrid,
-		),
+		container: content,
+		buttons:   buttons,
 	}
 }
 
+// Highlight marks a region or subregion as the suggested pick, e.g. when a
+// dashboard card opens the game for it
+func (r *RegionSelector) Highlight(region string) {
+	for name, button := range r.buttons {
+		button.Importance = widget.MediumImportance
+		if name == region {
+			button.Importance = widget.HighImportance
+		}
+		button.Refresh()
+	}
+}
+
+// NewRoundCountPicker offers fixed round counts; 0 is shown as "All"
+func NewRoundCountPicker(counts []int, onChange func(int)) *widget.RadioGroup {
+	labels := make([]string, len(counts))
+	for i, count := range counts {
+		if count == 0 {
+			labels[i] = lang.X("rounds.all", "All")
+		} else {
+			labels[i] = fmt.Sprintf(lang.X("rounds.count", "%d rounds"), count)
+		}
+	}
+
+	picker := widget.NewRadioGroup(labels, func(selected string) {
+		for i, label := range labels {
+			if label == selected && onChange != nil {
+				onChange(counts[i])
+			}
+		}
+	})
+	picker.Horizontal = true
+	picker.Required = true
+	picker.SetSelected(labels[0])
+	return picker
+}
+
 func (r *RegionSelector) GetContainer() *fyne.Container {
 	return r.container
 }
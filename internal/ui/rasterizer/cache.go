@@ -0,0 +1,67 @@
+package rasterizer
+
+import (
+	"image"
+	"sync"
+)
+
+// Key identifies a rendered image, e.g. a country shape at a given size
+type Key struct {
+	ID     string
+	Width  int
+	Height int
+	Dark   bool
+}
+
+// Cache keeps recently rendered images so resizes and refreshes don't
+// redraw them. It is safe for concurrent use, so images can be rendered
+// ahead of time from a background goroutine.
+type Cache struct {
+	mu    sync.Mutex
+	limit int
+	items map[Key]*image.RGBA
+	order []Key // oldest first
+}
+
+// NewCache creates a cache holding at most limit images
+func NewCache(limit int) *Cache {
+	return &Cache{
+		limit: limit,
+		items: make(map[Key]*image.RGBA),
+	}
+}
+
+// Get returns a cached image
+func (c *Cache) Get(key Key) (*image.RGBA, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	img, ok := c.items[key]
+	return img, ok
+}
+
+// Put stores an image, evicting the oldest one when the cache is full
+func (c *Cache) Put(key Key, img *image.RGBA) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.items[key]; exists {
+		c.items[key] = img
+		return
+	}
+	if len(c.order) >= c.limit && len(c.order) > 0 {
+		delete(c.items, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.items[key] = img
+	c.order = append(c.order, key)
+}
+
+// GetOrRender returns the cached image for key, rendering and storing it
+// first if needed
+func (c *Cache) GetOrRender(key Key, render func() *image.RGBA) *image.RGBA {
+	if img, ok := c.Get(key); ok {
+		return img
+	}
+	img := render()
+	c.Put(key, img)
+	return img
+}
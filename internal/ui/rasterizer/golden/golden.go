@@ -0,0 +1,100 @@
+// Package golden compares rendered images with PNG files checked in under a
+// package's testdata directory. Run the tests with UPDATE_GOLDEN=1 to write
+// the current renders as the new golden images.
+package golden
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	// tolerance is how far a channel may drift, e.g. from floating point
+	// differences between architectures, before a pixel counts as changed
+	tolerance = 2
+	// maxChanged is the share of pixels that may change, so an anti-aliased
+	// edge that flips a pixel doesn't fail the test
+	maxChanged = 0.001
+)
+
+// Compare fails t if img differs from testdata/<name>.png
+func Compare(t *testing.T, name string, img *image.RGBA) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".png")
+
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		if err := write(path, img); err != nil {
+			t.Fatalf("writing %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := read(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v (run with UPDATE_GOLDEN=1 to create it)", path, err)
+	}
+	if want.Bounds() != img.Bounds() {
+		t.Fatalf("%s: rendered %v, golden image is %v", name, img.Bounds(), want.Bounds())
+	}
+
+	changed := 0
+	for i := 0; i < len(img.Pix); i += 4 {
+		for c := i; c < i+4; c++ {
+			if diff := int(img.Pix[c]) - int(want.Pix[c]); diff > tolerance || diff < -tolerance {
+				changed++
+				break
+			}
+		}
+	}
+	pixels := len(img.Pix) / 4
+	if float64(changed) > maxChanged*float64(pixels) {
+		// Kept after the test so it can be compared by eye
+		actual := filepath.Join(os.TempDir(), "golden-"+name+".png")
+		if err := write(actual, img); err == nil {
+			t.Errorf("%s: %d of %d pixels differ from the golden image; the render is at %s", name, changed, pixels, actual)
+		} else {
+			t.Errorf("%s: %d of %d pixels differ from the golden image", name, changed, pixels)
+		}
+	}
+}
+
+func read(path string) (*image.RGBA, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	decoded, err := png.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	if rgba, ok := decoded.(*image.RGBA); ok {
+		return rgba, nil
+	}
+	// PNGs decode as NRGBA when alpha varies; convert to compare premultiplied
+	rgba := image.NewRGBA(decoded.Bounds())
+	for y := rgba.Rect.Min.Y; y < rgba.Rect.Max.Y; y++ {
+		for x := rgba.Rect.Min.X; x < rgba.Rect.Max.X; x++ {
+			rgba.Set(x, y, decoded.At(x, y))
+		}
+	}
+	return rgba, nil
+}
+
+func write(path string, img *image.RGBA) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
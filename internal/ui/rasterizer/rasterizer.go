@@ -0,0 +1,465 @@
+package rasterizer
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+)
+
+// subsamples is the number of sub-scanlines per pixel row used for
+// anti-aliasing; horizontal coverage is computed exactly
+const subsamples = 4
+
+// Shape is a set of closed rings in planar coordinates with y pointing up.
+// Rings are filled with the even-odd rule, so holes need no special marking.
+type Shape struct {
+	rings  [][][2]float64
+	bounds frame
+}
+
+// frame is the extent of shape coordinates that gets fitted into an image
+type frame struct {
+	minX, maxX, minY, maxY float64
+}
+
+// NewShape builds a shape from GeoJSON style polygons ([polygon][ring][point]{x, y})
+func NewShape(polygons [][][][]float64) *Shape {
+	s := &Shape{}
+	first := true
+	for _, polygon := range polygons {
+		for _, ring := range polygon {
+			points := make([][2]float64, 0, len(ring))
+			for _, point := range ring {
+				if len(point) < 2 {
+					continue
+				}
+				x, y := point[0], point[1]
+				points = append(points, [2]float64{x, y})
+				if first {
+					s.bounds = frame{x, x, y, y}
+					first = false
+					continue
+				}
+				s.bounds = s.bounds.extend(x, y)
+			}
+			if len(points) >= 3 {
+				s.rings = append(s.rings, points)
+			}
+		}
+	}
+	return s
+}
+
+// Empty reports whether the shape has no area to draw
+func (s *Shape) Empty() bool {
+	return len(s.rings) == 0 || s.bounds.empty()
+}
+
+func (f frame) empty() bool {
+	return f.minX == f.maxX || f.minY == f.maxY
+}
+
+func (f frame) extend(x, y float64) frame {
+	return frame{math.Min(f.minX, x), math.Max(f.maxX, x), math.Min(f.minY, y), math.Max(f.maxY, y)}
+}
+
+func (f frame) union(other frame) frame {
+	return f.extend(other.minX, other.minY).extend(other.maxX, other.maxY)
+}
+
+// Options control how a shape is drawn. Rotate, Mirror, Area and Outline
+// only apply to Render.
+type Options struct {
+	Width, Height int
+	Color         color.RGBA
+	// Fit is the share of the image the shape may fill, e.g. 0.9 leaves a margin
+	Fit       float64
+	AntiAlias bool
+	// Rotate turns the shape clockwise about its centre, in radians
+	Rotate float64
+	// Mirror flips the shape left to right
+	Mirror bool
+	// Area scales the shape to cover this share of the image instead of
+	// filling its bounding box, so compact and sprawling shapes look equally
+	// big. Shapes are never drawn larger than the fit.
+	Area float64
+	// Outline draws only the border, this many pixels wide, instead of a fill
+	Outline float64
+
+	shrink float64 // extra scale worked out by prepare for Area
+}
+
+// areaProbeSize is the longer side of the small image used to measure how
+// much of the frame a shape covers
+const areaProbeSize = 64
+
+// PixelPoint maps a point in shape coordinates to image pixels for an image
+// rendered with opts, e.g. to mark a city on the shape
+func (s *Shape) PixelPoint(x, y float64, opts Options) (float64, float64) {
+	t, opts := s.prepare(opts)
+	x, y = s.transformPoint(x, y, opts)
+	return t.bounds.point(x, y, opts)
+}
+
+// prepare returns the shape rotated and mirrored as opts ask, and opts with
+// the shrink needed to meet Area
+func (s *Shape) prepare(opts Options) (*Shape, Options) {
+	t := s
+	if opts.Rotate != 0 || opts.Mirror {
+		t = &Shape{}
+		for i, ring := range s.rings {
+			points := make([][2]float64, len(ring))
+			for j, p := range ring {
+				x, y := s.transformPoint(p[0], p[1], opts)
+				points[j] = [2]float64{x, y}
+				if i == 0 && j == 0 {
+					t.bounds = frame{x, x, y, y}
+				}
+				t.bounds = t.bounds.extend(x, y)
+			}
+			t.rings = append(t.rings, points)
+		}
+	}
+
+	opts.shrink = 1
+	if opts.Area > 0 && !t.Empty() && opts.Width > 0 && opts.Height > 0 {
+		// Measure the covered share on a small copy of the image
+		probe := opts
+		probe.shrink = 1
+		ratio := float64(areaProbeSize) / float64(max(opts.Width, opts.Height))
+		probe.Width = max(1, int(float64(opts.Width)*ratio))
+		probe.Height = max(1, int(float64(opts.Height)*ratio))
+		img := image.NewRGBA(image.Rect(0, 0, probe.Width, probe.Height))
+		fillShape(img, t, t.bounds, probe, color.RGBA{255, 255, 255, 255})
+
+		covered := 0.0
+		for i := 3; i < len(img.Pix); i += 4 {
+			covered += float64(img.Pix[i]) / 255
+		}
+		covered /= float64(probe.Width * probe.Height)
+		if covered > opts.Area {
+			opts.shrink = math.Sqrt(opts.Area / covered)
+		}
+	}
+	return t, opts
+}
+
+// transformPoint mirrors and rotates a point about the centre of the shape
+func (s *Shape) transformPoint(x, y float64, opts Options) (float64, float64) {
+	cx, cy := (s.bounds.minX+s.bounds.maxX)/2, (s.bounds.minY+s.bounds.maxY)/2
+	dx, dy := x-cx, y-cy
+	if opts.Mirror {
+		dx = -dx
+	}
+	if opts.Rotate == 0 {
+		return cx + dx, cy + dy
+	}
+	// y points up, so a clockwise turn is a negative angle
+	sin, cos := math.Sincos(-opts.Rotate)
+	return cx + dx*cos - dy*sin, cy + dx*sin + dy*cos
+}
+
+// point centres the frame in the image, scaled to fit, and flips y
+func (f frame) point(x, y float64, opts Options) (float64, float64) {
+	fit := opts.Fit
+	if fit <= 0 {
+		fit = 1
+	}
+	if opts.shrink > 0 {
+		fit *= opts.shrink
+	}
+	w, h := float64(opts.Width), float64(opts.Height)
+	scale := math.Min(w/(f.maxX-f.minX), h/(f.maxY-f.minY)) * fit
+	offsetX := (w - (f.maxX-f.minX)*scale) / 2
+	offsetY := (h - (f.maxY-f.minY)*scale) / 2
+	return (x-f.minX)*scale + offsetX, h - (y-f.minY)*scale - offsetY
+}
+
+// DrawDot paints an anti-aliased filled circle over img
+func DrawDot(img *image.RGBA, cx, cy, radius float64, c color.RGBA) {
+	bounds := img.Bounds()
+	minX := int(math.Max(float64(bounds.Min.X), math.Floor(cx-radius-1)))
+	maxX := int(math.Min(float64(bounds.Max.X-1), math.Ceil(cx+radius+1)))
+	minY := int(math.Max(float64(bounds.Min.Y), math.Floor(cy-radius-1)))
+	maxY := int(math.Min(float64(bounds.Max.Y-1), math.Ceil(cy+radius+1)))
+
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			distance := math.Hypot(float64(x)+0.5-cx, float64(y)+0.5-cy)
+			alpha := math.Max(0, math.Min(1, radius-distance+0.5))
+			if alpha == 0 {
+				continue
+			}
+			// Blend the premultiplied colour over what is already there
+			i := img.PixOffset(x, y)
+			a := alpha * float64(c.A) / 255
+			img.Pix[i] = uint8(float64(c.R)*a + float64(img.Pix[i])*(1-a) + 0.5)
+			img.Pix[i+1] = uint8(float64(c.G)*a + float64(img.Pix[i+1])*(1-a) + 0.5)
+			img.Pix[i+2] = uint8(float64(c.B)*a + float64(img.Pix[i+2])*(1-a) + 0.5)
+			img.Pix[i+3] = uint8(255*a + float64(img.Pix[i+3])*(1-a) + 0.5)
+		}
+	}
+}
+
+// edge is a ring segment in pixel space, stored top to bottom
+type edge struct {
+	x0, y0, x1, y1 float64
+	dxdy           float64
+}
+
+// Render draws the shape centred in a new image, scaled to fit
+func Render(s *Shape, opts Options) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, opts.Width, opts.Height))
+	if s.Empty() || opts.Width <= 0 || opts.Height <= 0 {
+		return img
+	}
+	t, opts := s.prepare(opts)
+	if opts.Outline > 0 {
+		strokeShape(img, t, t.bounds, opts, opts.Outline, opts.Color)
+		return img
+	}
+	fillShape(img, t, t.bounds, opts, opts.Color)
+	return img
+}
+
+// Layer is one shape of a map drawn with RenderLayers
+type Layer struct {
+	Shape *Shape
+	Fill  color.RGBA // a zero alpha leaves the shape unfilled
+	// Outline is drawn StrokeWidth pixels wide along every ring
+	Outline     color.RGBA
+	StrokeWidth float64
+	// Focus layers decide the frame; the others are cut off at the edges
+	Focus bool
+}
+
+// RenderLayers draws several shapes into one image, e.g. a country among its
+// neighbours. All layers share one frame that fits the focus layers, or all
+// layers if none is marked, and later layers paint over earlier ones.
+func RenderLayers(layers []Layer, opts Options) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, opts.Width, opts.Height))
+	DrawLayers(img, layers, opts)
+	return img
+}
+
+// DrawLayers paints layers over an image made by RenderLayers, e.g. to
+// recolour one shape without drawing the rest again. The layers must give
+// the same frame, so pass the same focus layers.
+func DrawLayers(img *image.RGBA, layers []Layer, opts Options) {
+	if opts.Width <= 0 || opts.Height <= 0 {
+		return
+	}
+
+	anyFocus := false
+	for _, layer := range layers {
+		anyFocus = anyFocus || layer.Focus
+	}
+	var bounds frame
+	first := true
+	for _, layer := range layers {
+		if layer.Shape == nil || layer.Shape.Empty() || (anyFocus && !layer.Focus) {
+			continue
+		}
+		if first {
+			bounds = layer.Shape.bounds
+			first = false
+			continue
+		}
+		bounds = bounds.union(layer.Shape.bounds)
+	}
+	if first {
+		return
+	}
+
+	for _, layer := range layers {
+		if layer.Shape == nil || layer.Shape.Empty() {
+			continue
+		}
+		if layer.Fill.A > 0 {
+			fillShape(img, layer.Shape, bounds, opts, layer.Fill)
+		}
+		if layer.StrokeWidth > 0 && layer.Outline.A > 0 {
+			strokeShape(img, layer.Shape, bounds, opts, layer.StrokeWidth, layer.Outline)
+		}
+	}
+}
+
+// fillShape fills the shape's rings over img, placed within bounds
+func fillShape(img *image.RGBA, s *Shape, bounds frame, opts Options, c color.RGBA) {
+	edges := make([]edge, 0, 256)
+	for _, ring := range s.rings {
+		for i := range ring {
+			p1, p2 := ring[i], ring[(i+1)%len(ring)]
+			x0, y0 := bounds.point(p1[0], p1[1], opts)
+			x1, y1 := bounds.point(p2[0], p2[1], opts)
+			if y0 == y1 {
+				continue
+			}
+			if y0 > y1 {
+				x0, y0, x1, y1 = x1, y1, x0, y0
+			}
+			edges = append(edges, edge{x0, y0, x1, y1, (x1 - x0) / (y1 - y0)})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].y0 < edges[j].y0 })
+
+	samples := 1
+	if opts.AntiAlias {
+		samples = subsamples
+	}
+	fill(img, edges, samples, c)
+}
+
+// strokeShape draws the outline of every ring with anti-aliased lines.
+// Coverage is collected per pixel first so joints aren't painted twice.
+func strokeShape(img *image.RGBA, s *Shape, bounds frame, opts Options, width float64, c color.RGBA) {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	coverage := make([]float32, w*h)
+	half := width / 2
+
+	for _, ring := range s.rings {
+		for i := range ring {
+			p1, p2 := ring[i], ring[(i+1)%len(ring)]
+			x0, y0 := bounds.point(p1[0], p1[1], opts)
+			x1, y1 := bounds.point(p2[0], p2[1], opts)
+
+			minX := int(math.Max(0, math.Floor(math.Min(x0, x1)-half-1)))
+			maxX := int(math.Min(float64(w-1), math.Ceil(math.Max(x0, x1)+half+1)))
+			minY := int(math.Max(0, math.Floor(math.Min(y0, y1)-half-1)))
+			maxY := int(math.Min(float64(h-1), math.Ceil(math.Max(y0, y1)+half+1)))
+			for y := minY; y <= maxY; y++ {
+				for x := minX; x <= maxX; x++ {
+					d := segmentDistance(float64(x)+0.5, float64(y)+0.5, x0, y0, x1, y1)
+					cov := float32(math.Max(0, math.Min(1, half-d+0.5)))
+					if cov > coverage[y*w+x] {
+						coverage[y*w+x] = cov
+					}
+				}
+			}
+		}
+	}
+
+	for y := 0; y < h; y++ {
+		writeRow(img, y, coverage[y*w:(y+1)*w], c)
+	}
+}
+
+// segmentDistance is the distance from (px, py) to the segment (x0, y0)-(x1, y1)
+func segmentDistance(px, py, x0, y0, x1, y1 float64) float64 {
+	dx, dy := x1-x0, y1-y0
+	lengthSq := dx*dx + dy*dy
+	t := 0.0
+	if lengthSq > 0 {
+		t = math.Max(0, math.Min(1, ((px-x0)*dx+(py-y0)*dy)/lengthSq))
+	}
+	return math.Hypot(px-(x0+t*dx), py-(y0+t*dy))
+}
+
+// fill walks the scanlines with an active edge list and accumulates
+// coverage per pixel, then writes premultiplied colour into Pix
+func fill(img *image.RGBA, edges []edge, samples int, c color.RGBA) {
+	width, height := img.Rect.Dx(), img.Rect.Dy()
+	coverage := make([]float32, width+1)
+	crossings := make([]float64, 0, 64)
+	active := make([]edge, 0, 64)
+	next := 0
+	weight := float32(1) / float32(samples)
+
+	for y := 0; y < height; y++ {
+		for i := range coverage {
+			coverage[i] = 0
+		}
+		touched := false
+
+		for sub := 0; sub < samples; sub++ {
+			scanY := float64(y) + (float64(sub)+0.5)/float64(samples)
+
+			// Add edges starting above the scanline, drop finished ones
+			for next < len(edges) && edges[next].y0 <= scanY {
+				active = append(active, edges[next])
+				next++
+			}
+			kept := active[:0]
+			for _, e := range active {
+				if e.y1 > scanY {
+					kept = append(kept, e)
+				}
+			}
+			active = kept
+
+			crossings = crossings[:0]
+			for _, e := range active {
+				if e.y0 <= scanY {
+					crossings = append(crossings, e.x0+(scanY-e.y0)*e.dxdy)
+				}
+			}
+			if len(crossings) < 2 {
+				continue
+			}
+			sort.Float64s(crossings)
+
+			for i := 0; i+1 < len(crossings); i += 2 {
+				if addSpan(coverage, crossings[i], crossings[i+1], weight, samples > 1) {
+					touched = true
+				}
+			}
+		}
+
+		if touched {
+			writeRow(img, y, coverage[:width], c)
+		}
+	}
+}
+
+// addSpan adds coverage for [x0, x1). With anti-aliasing the partially
+// covered end pixels get fractional coverage, otherwise pixel centres decide.
+func addSpan(coverage []float32, x0, x1 float64, weight float32, antiAlias bool) bool {
+	width := float64(len(coverage) - 1)
+	x0 = math.Max(0, math.Min(width, x0))
+	x1 = math.Max(0, math.Min(width, x1))
+	if x1 <= x0 {
+		return false
+	}
+
+	if !antiAlias {
+		start := int(math.Ceil(x0 - 0.5))
+		end := int(math.Floor(x1 - 0.5))
+		for x := start; x <= end && x < len(coverage)-1; x++ {
+			coverage[x] += weight
+		}
+		return start <= end
+	}
+
+	first, last := int(x0), int(x1)
+	if first == last {
+		coverage[first] += weight * float32(x1-x0)
+		return true
+	}
+	coverage[first] += weight * float32(float64(first+1)-x0)
+	for x := first + 1; x < last; x++ {
+		coverage[x] += weight
+	}
+	coverage[last] += weight * float32(x1-float64(last))
+	return true
+}
+
+func writeRow(img *image.RGBA, y int, coverage []float32, c color.RGBA) {
+	row := img.Pix[y*img.Stride : y*img.Stride+len(coverage)*4]
+	for x, cov := range coverage {
+		if cov <= 0 {
+			continue
+		}
+		if cov > 1 {
+			cov = 1
+		}
+		// image.RGBA is premultiplied, so every channel scales with coverage
+		// and is composited over what is already drawn
+		i := x * 4
+		keep := 1 - float32(c.A)/255*cov
+		row[i] = uint8(float32(c.R)*cov + float32(row[i])*keep + 0.5)
+		row[i+1] = uint8(float32(c.G)*cov + float32(row[i+1])*keep + 0.5)
+		row[i+2] = uint8(float32(c.B)*cov + float32(row[i+2])*keep + 0.5)
+		row[i+3] = uint8(float32(c.A)*cov + float32(row[i+3])*keep + 0.5)
+	}
+}
@@ -0,0 +1,129 @@
+package rasterizer
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"flagged-it/internal/ui/rasterizer/golden"
+)
+
+var black = color.RGBA{0, 0, 0, 255}
+
+// square returns a ring around (cx, cy); clockwise reverses its winding
+func square(cx, cy, half float64, clockwise bool) [][]float64 {
+	ring := [][]float64{{cx - half, cy - half}, {cx + half, cy - half}, {cx + half, cy + half}, {cx - half, cy + half}}
+	if clockwise {
+		for i, j := 0, len(ring)-1; i < j; i, j = i+1, j-1 {
+			ring[i], ring[j] = ring[j], ring[i]
+		}
+	}
+	return ring
+}
+
+func circle(cx, cy, r float64, points int) [][]float64 {
+	ring := make([][]float64, points)
+	for i := range ring {
+		angle := 2 * math.Pi * float64(i) / float64(points)
+		ring[i] = []float64{cx + r*math.Cos(angle), cy + r*math.Sin(angle)}
+	}
+	return ring
+}
+
+func alphaAt(img *image.RGBA, x, y float64) uint8 {
+	return img.RGBAAt(int(x), int(y)).A
+}
+
+// Holes are filled by the even-odd rule whatever their winding, as GeoJSON
+// from different sources doesn't agree on it
+func TestRenderEvenOddHoles(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		clockwise bool
+	}{
+		{"hole_same_winding", false},
+		{"hole_opposite_winding", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			shape := NewShape([][][][]float64{{
+				square(0, 0, 10, false),
+				square(0, 0, 4, tt.clockwise),
+				circle(0, 0, 2, 24), // an island in the lake
+			}})
+			opts := Options{Width: 64, Height: 64, Color: black, Fit: 0.9, AntiAlias: true}
+			img := Render(shape, opts)
+
+			checks := []struct {
+				x, y float64
+				want uint8
+			}{
+				{7, 7, 255}, // the ring around the hole
+				{3, 0, 0},   // the hole
+				{0, 0, 255}, // the island
+			}
+			for _, c := range checks {
+				px, py := shape.PixelPoint(c.x, c.y, opts)
+				if got := alphaAt(img, px, py); got != c.want {
+					t.Errorf("alpha at (%v, %v) = %d, want %d", c.x, c.y, got, c.want)
+				}
+			}
+			golden.Compare(t, tt.name, img)
+		})
+	}
+}
+
+// Anti-aliasing gives edges partial coverage; without it every pixel is
+// either painted or not
+func TestRenderAntiAliasedEdges(t *testing.T) {
+	shape := NewShape([][][][]float64{
+		{circle(0, 0, 10, 90)},
+		{{{12, -10}, {30, -10}, {14, 10}}}, // slanted edges
+	})
+
+	for _, tt := range []struct {
+		name      string
+		antiAlias bool
+	}{
+		{"edges_antialiased", true},
+		{"edges_aliased", false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			img := Render(shape, Options{Width: 96, Height: 64, Color: black, Fit: 0.9, AntiAlias: tt.antiAlias})
+
+			partial := 0
+			for i := 3; i < len(img.Pix); i += 4 {
+				if a := img.Pix[i]; a != 0 && a != 255 {
+					partial++
+				}
+			}
+			if tt.antiAlias && partial == 0 {
+				t.Error("no partially covered pixels along the edges")
+			}
+			if !tt.antiAlias && partial > 0 {
+				t.Errorf("%d partially covered pixels without anti-aliasing", partial)
+			}
+			golden.Compare(t, tt.name, img)
+		})
+	}
+}
+
+// Layers share one frame fitted to the focus layers and paint in order
+func TestRenderLayersFocus(t *testing.T) {
+	background := NewShape([][][][]float64{{square(0, 0, 20, false)}})
+	focus := NewShape([][][][]float64{{circle(5, 0, 4, 48)}})
+	opts := Options{Width: 64, Height: 64, Fit: 0.8, AntiAlias: true}
+
+	img := RenderLayers([]Layer{
+		{Shape: background, Fill: color.RGBA{200, 200, 200, 255}},
+		{Shape: focus, Fill: color.RGBA{59, 130, 246, 255}, Outline: black, StrokeWidth: 1.5, Focus: true},
+	}, opts)
+
+	if got := img.RGBAAt(32, 32); got != (color.RGBA{59, 130, 246, 255}) {
+		t.Errorf("centre is %v, want the focus fill", got)
+	}
+	if got := img.RGBAAt(1, 1); got != (color.RGBA{200, 200, 200, 255}) {
+		t.Errorf("corner is %v, want the background cut off at the edge", got)
+	}
+	golden.Compare(t, "layers_focus", img)
+}
@@ -101,6 +101,18 @@ func (d *Dashboard) setupUI() {
 		d.navigateFunc("guessing")
 	})
 
+	currencyBtn := components.NewButtonWithIcon(lang.X("game.currency.title", "Guess the Currency"), theme.StorageIcon(), func() {
+		d.navigateFunc("currency")
+	})
+
+	languagesBtn := components.NewButtonWithIcon(lang.X("game.languages.title", "Official Languages"), theme.AccountIcon(), func() {
+		d.navigateFunc("languages")
+	})
+
+	rankingBtn := components.NewButtonWithIcon(lang.X("game.ranking.title", "Rank the Countries"), theme.MenuIcon(), func() {
+		d.navigateFunc("ranking")
+	})
+
 	// Game buttons in responsive grid
 	columns := 2
 	if utils.IsMobile() {
@@ -114,6 +126,9 @@ func (d *Dashboard) setupUI() {
 		factGuessBtn,
 		higher_lowerBtn,
 		guessingBtn,
+		currencyBtn,
+		languagesBtn,
+		rankingBtn,
 	)
 
 	// Promotional cards section
@@ -0,0 +1,101 @@
+package screens
+
+import (
+	"flagged-it/internal/utils"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2/lang"
+)
+
+// variantLabel translates the settings a score was saved with, which games
+// store as space separated English words such as "60s typed hard", into the
+// labels players picked them by
+func variantLabel(gameMode, variant string) string {
+	if gameMode == "higher_lower" {
+		return utils.CountryMetric(variant).Label()
+	}
+
+	words := strings.Fields(variant)
+	var labels []string
+	for i := 0; i < len(words); i++ {
+		// Phrases and counted settings span several words
+		if i+2 < len(words) && strings.Join(words[i:i+3], " ") == "true or false" {
+			labels = append(labels, lang.X("game.facts.format_true_false", "True or false"))
+			i += 2
+			continue
+		}
+		if i+1 < len(words) {
+			if label, ok := variantPhrase(words[i], words[i+1]); ok {
+				labels = append(labels, label)
+				i++
+				continue
+			}
+		}
+		labels = append(labels, variantWord(gameMode, words[i]))
+	}
+	return strings.Join(labels, ", ")
+}
+
+// variantPhrase translates a two word setting such as "letter A" or "5 min"
+func variantPhrase(first, second string) (string, bool) {
+	switch first + " " + second {
+	case "multiple choice":
+		return lang.X("game.facts.format_choice", "Multiple choice"), true
+	case "same size":
+		return lang.X("game.shape.normalize", "Same size"), true
+	}
+	if first == "letter" {
+		return fmt.Sprintf(lang.X("game.list.starting_with", "Starting with %s"), second), true
+	}
+	count, err := strconv.Atoi(first)
+	if err != nil {
+		return "", false
+	}
+	switch second {
+	case "min":
+		return fmt.Sprintf(lang.X("game.list.minutes", "%d min"), count), true
+	case "lives":
+		return fmt.Sprintf(lang.X("game.hangman.lives", "%d lives"), count), true
+	case "rounds":
+		return fmt.Sprintf(lang.X("rounds.count", "%d rounds"), count), true
+	}
+	return "", false
+}
+
+// variantWord translates a single word setting, leaving unknown ones as saved
+func variantWord(gameMode, word string) string {
+	switch word {
+	case "typed":
+		return lang.X("game.flag.answer_typed", "Type the answer")
+	case "medium":
+		return lang.X("difficulty.medium", "Medium")
+	case "hard":
+		return lang.X("difficulty.hard", "Hard")
+	case "all":
+		return lang.X("rounds.all", "All")
+	case "rotated":
+		return lang.X("game.shape.rotate", "Rotate")
+	case "mirrored":
+		return lang.X("game.shape.mirror", "Mirror")
+	case "outline":
+		return lang.X("game.shape.outline", "Outline only")
+	case "map":
+		return lang.X("game.list.board_map", "Map")
+	case "capitals":
+		if gameMode == "hangman" {
+			return lang.X("game.hangman.category_capitals", "Capitals")
+		}
+		return lang.X("game.list.answer_capitals", "Capitals")
+	case "currencies":
+		return lang.X("game.hangman.category_currencies", "Currencies")
+	case "languages":
+		return lang.X("game.hangman.category_languages", "Languages")
+	}
+	// Blitz time limits are saved as e.g. "60s"
+	if seconds, err := strconv.Atoi(strings.TrimSuffix(word, "s")); err == nil && strings.HasSuffix(word, "s") {
+		return fmt.Sprintf(lang.X("scoreboard.seconds", "%ds"), seconds)
+	}
+	return word
+}
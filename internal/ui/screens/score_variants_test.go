@@ -0,0 +1,23 @@
+package screens
+
+import "testing"
+
+func TestVariantLabel(t *testing.T) {
+	tests := []struct {
+		gameMode, variant, want string
+	}{
+		{"higher_lower", "population", "Population"},
+		{"list", "capitals letter A map 5 min", "Capitals, Starting with A, Map, 5 min"},
+		{"flag_blitz", "60s typed hard", "60s, Type the answer, Hard"},
+		{"flag", "20 rounds", "20 rounds"},
+		{"shape_blitz", "30s rotated same size", "30s, Rotate, Same size"},
+		{"facts", "true or false", "True or false"},
+		{"hangman", "currencies 8 lives", "Currencies, 8 lives"},
+		{"flag", "unknown", "unknown"},
+	}
+	for _, tt := range tests {
+		if got := variantLabel(tt.gameMode, tt.variant); got != tt.want {
+			t.Errorf("variantLabel(%q, %q) = %q, want %q", tt.gameMode, tt.variant, got, tt.want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"flagged-it/internal/utils"
 	"fmt"
 	"image/color"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -32,6 +33,8 @@ func (s *Scoreboard) setupUI() {
 
 	// Get all scores
 	allScores := utils.GetScoreboard()
+	// Scoreboards saved by older versions may not be in ranked order
+	utils.SortScores(allScores)
 
 	// Group by game mode
 	scoresByGame := make(map[string][]utils.ScoreEntry)
@@ -51,15 +54,23 @@ func (s *Scoreboard) setupUI() {
 		sections = append(sections, container.NewPadded(emptyLabel))
 	} else {
 		// Add section for each game mode
-		gameModes := []string{"flag", "shape", "hangman", "facts", "list", "higher_lower", "guessing"}
+		gameModes := []string{"flag", "flag_blitz", "flag_survival", "shape", "shape_blitz", "shape_survival", "hangman", "facts", "facts_survival", "list", "higher_lower", "guessing", "currency", "languages", "ranking"}
 		gameNames := map[string]string{
-			"flag":         lang.X("game.flag.title", "Guess by Flag"),
-			"shape":        lang.X("game.shape.title", "Guess by Shape"),
-			"hangman":      lang.X("game.hangman.title", "Hangman"),
-			"facts":        lang.X("game.facts.title", "Guess by Facts"),
-			"list":         lang.X("game.list.title", "List All Countries"),
-			"higher_lower": lang.X("game.higher_lower.title", "Higher or Lower"),
-			"guessing":     lang.X("game.guessing.title", "What Country is This"),
+			"flag":           lang.X("game.flag.title", "Guess by Flag"),
+			"flag_blitz":     lang.X("scoreboard.flag_blitz", "Guess by Flag (Blitz)"),
+			"flag_survival":  lang.X("scoreboard.flag_survival", "Guess by Flag (Survival)"),
+			"shape":          lang.X("game.shape.title", "Guess by Shape"),
+			"shape_blitz":    lang.X("scoreboard.shape_blitz", "Guess by Shape (Blitz)"),
+			"shape_survival": lang.X("scoreboard.shape_survival", "Guess by Shape (Survival)"),
+			"hangman":        lang.X("game.hangman.title", "Hangman"),
+			"facts":          lang.X("game.facts.title", "Guess by Facts"),
+			"facts_survival": lang.X("scoreboard.facts_survival", "Guess by Facts (Survival)"),
+			"list":           lang.X("game.list.title", "List All Countries"),
+			"higher_lower":   lang.X("game.higher_lower.title", "Higher or Lower"),
+			"guessing":       lang.X("game.guessing.title", "What Country is This"),
+			"currency":       lang.X("game.currency.title", "Guess the Currency"),
+			"languages":      lang.X("game.languages.title", "Official Languages"),
+			"ranking":        lang.X("game.ranking.title", "Rank the Countries"),
 		}
 
 		for _, gameMode := range gameModes {
@@ -67,6 +78,14 @@ func (s *Scoreboard) setupUI() {
 			if !exists || len(scores) == 0 {
 				continue
 			}
+			// Survival runs are compared per region, so only keep each region's longest run
+			if strings.HasSuffix(gameMode, "_survival") {
+				scores = bestPerRegion(scores)
+			}
+			// Streaks are compared per metric, so only keep each metric's best
+			if gameMode == "higher_lower" {
+				scores = bestPerVariant(scores)
+			}
 
 			// Game title
 			gameTitle := widget.NewLabel(gameNames[gameMode])
@@ -118,13 +137,22 @@ func (s *Scoreboard) createScoreTable(scores []utils.ScoreEntry) *fyne.Container
 	headerDate := widget.NewLabel(lang.X("scoreboard.date", "Date"))
 	headerDate.TextStyle = fyne.TextStyle{Bold: true}
 
-	headerRow := container.NewGridWithColumns(4,
-		headerRank,
-		headerScore,
-		headerPercent,
-		headerDate,
-	)
-	rows = append(rows, headerRow)
+	// Only show the details column when some entry was played with a region or variant
+	showDetails := false
+	for _, score := range scores {
+		if score.Region != "" || score.Variant != "" {
+			showDetails = true
+			break
+		}
+	}
+
+	headerCells := []fyne.CanvasObject{headerRank, headerScore, headerPercent, headerDate}
+	if showDetails {
+		headerDetails := widget.NewLabel(lang.X("scoreboard.details", "Mode"))
+		headerDetails.TextStyle = fyne.TextStyle{Bold: true}
+		headerCells = append(headerCells, headerDetails)
+	}
+	rows = append(rows, container.NewGridWithColumns(len(headerCells), headerCells...))
 
 	// Data rows
 	for i, score := range scores {
@@ -136,18 +164,59 @@ func (s *Scoreboard) createScoreTable(scores []utils.ScoreEntry) *fyne.Container
 
 		dateText := widget.NewLabel(score.Date.Format("Jan 2, 15:04"))
 
-		row := container.NewGridWithColumns(4,
-			rank,
-			scoreText,
-			percentText,
-			dateText,
-		)
-		rows = append(rows, row)
+		cells := []fyne.CanvasObject{rank, scoreText, percentText, dateText}
+		if showDetails {
+			cells = append(cells, widget.NewLabel(scoreDetails(score)))
+		}
+		rows = append(rows, container.NewGridWithColumns(len(cells), cells...))
 	}
 
 	return container.NewVBox(rows...)
 }
 
+// scoreDetails describes the settings a score was played with, e.g. "Europe · 60s"
+func scoreDetails(score utils.ScoreEntry) string {
+	var parts []string
+	if score.Region != "" {
+		parts = append(parts, utils.TranslateRegion(score.Region))
+	}
+	if score.Variant != "" {
+		parts = append(parts, variantLabel(score.GameMode, score.Variant))
+	}
+	if score.Hints > 0 {
+		parts = append(parts, fmt.Sprintf(lang.X("scoreboard.hints", "%d hints"), score.Hints))
+	}
+	return strings.Join(parts, " · ")
+}
+
 func (s *Scoreboard) GetContent() *fyne.Container {
 	return s.content
 }
+
+// bestPerRegion keeps the first (best ranked) entry for each region
+func bestPerRegion(scores []utils.ScoreEntry) []utils.ScoreEntry {
+	seen := make(map[string]bool)
+	var best []utils.ScoreEntry
+	for _, score := range scores {
+		if seen[score.Region] {
+			continue
+		}
+		seen[score.Region] = true
+		best = append(best, score)
+	}
+	return best
+}
+
+// bestPerVariant keeps the first (best ranked) entry for each variant
+func bestPerVariant(scores []utils.ScoreEntry) []utils.ScoreEntry {
+	seen := make(map[string]bool)
+	var best []utils.ScoreEntry
+	for _, score := range scores {
+		if seen[score.Variant] {
+			continue
+		}
+		seen[score.Variant] = true
+		best = append(best, score)
+	}
+	return best
+}
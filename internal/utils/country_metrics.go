@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"fmt"
+
+	"flagged-it/internal/data/models"
+
+	"fyne.io/fyne/v2/lang"
+)
+
+// CountryMetric is a numeric country attribute that games can compare or rank by
+type CountryMetric string
+
+const (
+	MetricPopulation   CountryMetric = "population"
+	MetricArea         CountryMetric = "area"
+	MetricDensity      CountryMetric = "density"
+	MetricIndependence CountryMetric = "independence"
+	MetricTemperature  CountryMetric = "temperature"
+)
+
+// CountryMetrics lists all metrics in display order
+var CountryMetrics = []CountryMetric{
+	MetricPopulation,
+	MetricArea,
+	MetricDensity,
+	MetricIndependence,
+	MetricTemperature,
+}
+
+// Value returns the metric value for a country and whether the country has one
+func (m CountryMetric) Value(country models.Country) (float64, bool) {
+	switch m {
+	case MetricPopulation:
+		return float64(country.Population), country.Population > 0
+	case MetricArea:
+		return country.Area, country.Area > 0
+	case MetricDensity:
+		if country.Area <= 0 {
+			return 0, false
+		}
+		return float64(country.Population) / country.Area, true
+	case MetricIndependence:
+		return float64(country.Independence), country.Independence != 0
+	case MetricTemperature:
+		if country.Temperature == nil {
+			return 0, false
+		}
+		return *country.Temperature, true
+	}
+	return 0, false
+}
+
+// Label returns the translated metric name
+func (m CountryMetric) Label() string {
+	switch m {
+	case MetricPopulation:
+		return lang.X("metric.population", "Population")
+	case MetricArea:
+		return lang.X("metric.area", "Area")
+	case MetricDensity:
+		return lang.X("metric.density", "Population Density")
+	case MetricIndependence:
+		return lang.X("metric.independence", "Independence Year")
+	case MetricTemperature:
+		return lang.X("metric.temperature", "Average Temperature")
+	}
+	return string(m)
+}
+
+// Format renders the exact metric value with its unit, with separators
+// following the current locale
+func (m CountryMetric) Format(value float64) string {
+	switch m {
+	case MetricPopulation:
+		return FormatNumber(value, 0)
+	case MetricArea:
+		return m.withUnit(FormatNumber(value, 0))
+	case MetricDensity, MetricTemperature:
+		return m.withUnit(FormatNumber(value, 1))
+	case MetricIndependence:
+		// Years read wrong with digit grouping
+		if value < 0 {
+			return fmt.Sprintf(lang.X("metric.year_bc", "%.0f BC"), -value)
+		}
+		return fmt.Sprintf("%.0f", value)
+	}
+	return fmt.Sprintf("%g", value)
+}
+
+// FormatCompact renders a metric value short enough for a card, with large
+// numbers abbreviated
+func (m CountryMetric) FormatCompact(value float64) string {
+	switch m {
+	case MetricPopulation:
+		return FormatCompact(value)
+	case MetricArea:
+		return m.withUnit(FormatCompact(value))
+	}
+	return m.Format(value)
+}
+
+// withUnit appends the metric's unit to an already formatted number
+func (m CountryMetric) withUnit(number string) string {
+	switch m {
+	case MetricArea:
+		return fmt.Sprintf(lang.X("metric.area_value", "%s km²"), number)
+	case MetricDensity:
+		return fmt.Sprintf(lang.X("metric.density_value", "%s /km²"), number)
+	case MetricTemperature:
+		return fmt.Sprintf(lang.X("metric.temperature_value", "%s °C"), number)
+	}
+	return number
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"flagged-it/internal/data/models"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// TranslateCountry returns a country's common name in the current locale.
+// English keeps the dataset's own name, as do codes unknown to the CLDR tables.
+func TranslateCountry(country models.Country) string {
+	return countryNameIn(country, GetCurrentLocale())
+}
+
+func countryNameIn(country models.Country, locale string) string {
+	tag := language.Make(locale)
+	if base, _ := tag.Base(); base.String() == "en" {
+		return country.Name.Common
+	}
+
+	region, err := language.ParseRegion(country.CCA2)
+	if err != nil {
+		return country.Name.Common
+	}
+	// Locales CLDR doesn't cover, such as "C", have no namer
+	namer := display.Regions(tag)
+	if namer == nil {
+		return country.Name.Common
+	}
+	name := namer.Name(region)
+	if name == "" {
+		return country.Name.Common
+	}
+	return name
+}
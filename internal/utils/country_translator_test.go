@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"testing"
+
+	"flagged-it/internal/data/models"
+)
+
+func TestCountryNameIn(t *testing.T) {
+	germany := models.Country{CCA2: "DE", Name: models.CountryName{Common: "Germany"}}
+
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"en", "Germany"},
+		{"cs", "Německo"},
+		{"C", "Germany"},
+		{"C.UTF-8", "Germany"},
+	}
+	for _, tt := range tests {
+		if got := countryNameIn(germany, tt.locale); got != tt.want {
+			t.Errorf("countryNameIn(DE, %q) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+}
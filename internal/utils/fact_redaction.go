@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"flagged-it/internal/data/models"
+	"flagged-it/internal/translations"
+)
+
+// redactionMark replaces a name that would give the answer away
+const redactionMark = "███"
+
+// minRedactedLength skips names so short they would blank out unrelated
+// abbreviations
+const minRedactedLength = 3
+
+// RedactCountry blanks out a country's own names from a fact: its common and
+// official names, its capitals, its name in every app language and any extra
+// names given. Only whole words are matched, ignoring case.
+func RedactCountry(text string, country models.Country, extra ...string) string {
+	pattern := redactionPattern(country, extra)
+	if pattern == nil {
+		return text
+	}
+	return pattern.ReplaceAllString(text, "${1}"+redactionMark+"${3}")
+}
+
+func redactionPattern(country models.Country, extra []string) *regexp.Regexp {
+	names := []string{country.Name.Common, country.Name.Official}
+	names = append(names, country.Capital...)
+	names = append(names, extra...)
+	for _, info := range translations.TranslationsInfo {
+		names = append(names, countryNameIn(country, info.Name))
+	}
+
+	seen := make(map[string]bool)
+	var quoted []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if utf8.RuneCountInString(name) < minRedactedLength || seen[key] {
+			continue
+		}
+		seen[key] = true
+		quoted = append(quoted, regexp.QuoteMeta(name))
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+
+	// Longest first, so "Republic of the Congo" wins over "Congo"
+	sort.Slice(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	return regexp.MustCompile(`(?i)(^|[^\p{L}])(` + strings.Join(quoted, "|") + `)([^\p{L}]|$)`)
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// TranslateLanguage returns the name of an ISO 639 language code in the current locale
+// Falls back to English for locales the CLDR tables don't cover, such as "C",
+// and to the given name if the code itself is unknown
+func TranslateLanguage(code, fallback string) string {
+	tag, err := language.Parse(code)
+	if err != nil {
+		return fallback
+	}
+
+	namer := display.Tags(language.Make(GetCurrentLocale()))
+	if namer == nil {
+		namer = display.English.Tags()
+	}
+	name := namer.Name(tag)
+	if name == "" {
+		return fallback
+	}
+	return name
+}
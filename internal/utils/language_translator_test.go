@@ -0,0 +1,24 @@
+package utils
+
+import "testing"
+
+func TestTranslateLanguageUnknownLocale(t *testing.T) {
+	defer func(locale string) { currentLocale = locale }(currentLocale)
+
+	tests := []struct {
+		locale string
+		code   string
+		want   string
+	}{
+		{"C", "fra", "French"},
+		{"C.UTF-8", "deu", "German"},
+		{"C", "qaa", "Private"},
+		{"cs", "fra", "francouzština"},
+	}
+	for _, tt := range tests {
+		currentLocale = tt.locale
+		if got := TranslateLanguage(tt.code, "Private"); got != tt.want {
+			t.Errorf("TranslateLanguage(%q) in %q = %q, want %q", tt.code, tt.locale, got, tt.want)
+		}
+	}
+}
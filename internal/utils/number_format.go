@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+
+	"fyne.io/fyne/v2/lang"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// FormatNumber prints a number with the current locale's digit grouping and
+// decimal separator
+func FormatNumber(value float64, decimals int) string {
+	return localPrinter().Sprintf("%.*f", decimals, value)
+}
+
+// FormatCompact shortens large numbers for quick comparison, e.g. 1.4B or
+// 38.2M, keeping up to one decimal in the locale's notation
+func FormatCompact(value float64) string {
+	abs := math.Abs(value)
+	switch {
+	case abs >= 1e9:
+		return fmt.Sprintf(lang.X("number.billions", "%sB"), compactDigits(value/1e9))
+	case abs >= 1e6:
+		return fmt.Sprintf(lang.X("number.millions", "%sM"), compactDigits(value/1e6))
+	case abs >= 1e4:
+		return fmt.Sprintf(lang.X("number.thousands", "%sK"), compactDigits(value/1e3))
+	}
+	return FormatNumber(value, 0)
+}
+
+// compactDigits drops the decimal once it no longer matters, so 512.3M
+// reads as 512M and 45.0K as 45K
+func compactDigits(value float64) string {
+	if math.Abs(value) >= 100 || math.Round(value*10) == math.Round(value)*10 {
+		return FormatNumber(value, 0)
+	}
+	return FormatNumber(value, 1)
+}
+
+func localPrinter() *message.Printer {
+	return message.NewPrinter(language.Make(GetCurrentLocale()))
+}
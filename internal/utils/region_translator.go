@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"strings"
+
 	"fyne.io/fyne/v2/lang"
 )
 
@@ -18,11 +20,11 @@ func TranslateRegion(region string) string {
 		"Antarctica": "region.antarctica",
 	}
 
-	// Get translation key for this region
+	// Get translation key for this region; subregions use their snake case
+	// name, e.g. "region.western_europe"
 	key, exists := regionKeyMap[region]
 	if !exists {
-		// If no translation key exists, return the original region name
-		return region
+		key = "region." + strings.ReplaceAll(strings.ToLower(region), " ", "_")
 	}
 
 	// Get translated region name with fallback to original
@@ -0,0 +1,85 @@
+package utils
+
+import "sort"
+
+// maxScoresPerGroup is how many entries are kept per game mode, region and
+// variant
+const maxScoresPerGroup = 100
+
+// countBasedModes are ranked by number of correct answers rather than percent,
+// because their total depends on how fast or how long the player plays
+var countBasedModes = map[string]bool{
+	"flag_blitz":     true,
+	"shape_blitz":    true,
+	"flag_survival":  true,
+	"shape_survival": true,
+	"facts_survival": true,
+	"higher_lower":   true,
+}
+
+// IsCountBasedMode reports whether a game mode is ranked by score count
+func IsCountBasedMode(gameMode string) bool {
+	return countBasedModes[gameMode]
+}
+
+// rankedBefore reports whether score a ranks above score b
+func rankedBefore(a, b ScoreEntry) bool {
+	if IsCountBasedMode(a.GameMode) && a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	if a.Percent != b.Percent {
+		return a.Percent > b.Percent
+	}
+	return a.Date.After(b.Date)
+}
+
+// GetBestScore returns the best score for a game mode in a region, or nil
+func GetBestScore(gameMode, region string) *ScoreEntry {
+	var best *ScoreEntry
+	for _, s := range GetScoreboard() {
+		if s.GameMode != gameMode || s.Region != region {
+			continue
+		}
+		if best == nil || rankedBefore(s, *best) {
+			s := s
+			best = &s
+		}
+	}
+	return best
+}
+
+// trimScores keeps the best maxScoresPerGroup entries of each game mode,
+// region and variant. Bests are read back per region or variant, so one
+// region's runs must never push another's best out.
+func trimScores(scores []ScoreEntry) []ScoreEntry {
+	type group struct{ gameMode, region, variant string }
+	groups := make(map[group][]ScoreEntry)
+	for _, s := range scores {
+		key := group{s.GameMode, s.Region, s.Variant}
+		groups[key] = append(groups[key], s)
+	}
+
+	var trimmed []ScoreEntry
+	for _, groupScores := range groups {
+		sort.Slice(groupScores, func(i, j int) bool {
+			return rankedBefore(groupScores[i], groupScores[j])
+		})
+		if len(groupScores) > maxScoresPerGroup {
+			groupScores = groupScores[:maxScoresPerGroup]
+		}
+		trimmed = append(trimmed, groupScores...)
+	}
+	// Groups come out of the map in any order; keep each mode ranked as a whole
+	SortScores(trimmed)
+	return trimmed
+}
+
+// SortScores orders scores by game mode, best ranked first within each mode
+func SortScores(scores []ScoreEntry) {
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].GameMode != scores[j].GameMode {
+			return scores[i].GameMode < scores[j].GameMode
+		}
+		return rankedBefore(scores[i], scores[j])
+	})
+}
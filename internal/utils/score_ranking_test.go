@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTrimScoresKeepsBestPerRegion(t *testing.T) {
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	scores := []ScoreEntry{{GameMode: "flag_survival", Region: "Oceania", Score: 4, Date: date}}
+	for i := 0; i < 2*maxScoresPerGroup; i++ {
+		scores = append(scores, ScoreEntry{GameMode: "flag_survival", Region: "Europe", Score: 10 + i, Date: date})
+	}
+
+	trimmed := trimScores(scores)
+	if len(trimmed) != maxScoresPerGroup+1 {
+		t.Fatalf("kept %d entries, want %d", len(trimmed), maxScoresPerGroup+1)
+	}
+	lowestEurope := -1
+	keptOceania := false
+	for _, s := range trimmed {
+		switch s.Region {
+		case "Oceania":
+			keptOceania = true
+		case "Europe":
+			if lowestEurope < 0 || s.Score < lowestEurope {
+				lowestEurope = s.Score
+			}
+		}
+	}
+	if !keptOceania {
+		t.Error("Europe runs pushed out the only Oceania run")
+	}
+	if want := 10 + maxScoresPerGroup; lowestEurope != want {
+		t.Errorf("lowest Europe run kept is %d, want %d", lowestEurope, want)
+	}
+}
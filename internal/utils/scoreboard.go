@@ -19,6 +19,8 @@ type ScoreEntry struct {
 	Date     time.Time `json:"date"`
 	Duration int       `json:"duration"` // in seconds
 	Region   string    `json:"region,omitempty"`
+	Variant  string    `json:"variant,omitempty"` // mode settings, e.g. time limit
+	Hints    int       `json:"hints,omitempty"`   // hints used, for games that offer them
 }
 
 // GetScoreboard retrieves all scores from localStorage
@@ -49,28 +51,8 @@ func SaveScore(entry ScoreEntry) error {
 	entry.Date = time.Now()
 	scores = append(scores, entry)
 
-	// Keep only top 100 entries per game mode to avoid localStorage bloat
-	scoresByGame := make(map[string][]ScoreEntry)
-	for _, s := range scores {
-		scoresByGame[s.GameMode] = append(scoresByGame[s.GameMode], s)
-	}
-
-	// Sort and trim each game mode
-	var trimmedScores []ScoreEntry
-	for _, gameScores := range scoresByGame {
-		sort.Slice(gameScores, func(i, j int) bool {
-			if gameScores[i].Percent != gameScores[j].Percent {
-				return gameScores[i].Percent > gameScores[j].Percent
-			}
-			return gameScores[i].Date.After(gameScores[j].Date)
-		})
-
-		// Keep top 100 per game
-		if len(gameScores) > 100 {
-			gameScores = gameScores[:100]
-		}
-		trimmedScores = append(trimmedScores, gameScores...)
-	}
+	// Keep only the top entries of each kind of game to avoid bloat
+	trimmedScores := trimScores(scores)
 
 	// Save back to localStorage
 	data, err := json.Marshal(trimmedScores)
@@ -98,12 +80,9 @@ func GetTopScores(gameMode string, limit int) []ScoreEntry {
 		}
 	}
 
-	// Sort by percentage, then by date
+	// Sort by percentage (or count for timed modes), then by date
 	sort.Slice(filtered, func(i, j int) bool {
-		if filtered[i].Percent != filtered[j].Percent {
-			return filtered[i].Percent > filtered[j].Percent
-		}
-		return filtered[i].Date.After(filtered[j].Date)
+		return rankedBefore(filtered[i], filtered[j])
 	})
 
 	// Return top N
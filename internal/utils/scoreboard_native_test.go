@@ -0,0 +1,48 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package utils
+
+import "testing"
+
+func TestGetScoreboardRanksEachMode(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	saves := []ScoreEntry{
+		{GameMode: "flag_survival", Region: "Europe", Score: 3},
+		{GameMode: "higher_lower", Variant: "area", Score: 7},
+		{GameMode: "flag_survival", Region: "Asia", Score: 9},
+		{GameMode: "flag", Region: "Africa", Score: 5, Total: 10, Percent: 50},
+		{GameMode: "higher_lower", Variant: "population", Score: 12},
+		{GameMode: "flag_survival", Region: "Oceania", Score: 6},
+		{GameMode: "flag", Region: "Europe", Score: 9, Total: 10, Percent: 90},
+		{GameMode: "higher_lower", Variant: "density", Score: 2},
+	}
+	for _, entry := range saves {
+		if err := SaveScore(entry); err != nil {
+			t.Fatalf("SaveScore: %v", err)
+		}
+	}
+
+	want := map[string][]int{
+		"flag":          {9, 5},
+		"flag_survival": {9, 6, 3},
+		"higher_lower":  {12, 7, 2},
+	}
+	got := map[string][]int{}
+	for _, s := range GetScoreboard() {
+		got[s.GameMode] = append(got[s.GameMode], s.Score)
+	}
+	for mode, scores := range want {
+		if len(got[mode]) != len(scores) {
+			t.Fatalf("%s: got scores %v, want %v", mode, got[mode], scores)
+		}
+		for i := range scores {
+			if got[mode][i] != scores[i] {
+				t.Errorf("%s: got scores %v, want %v", mode, got[mode], scores)
+				break
+			}
+		}
+	}
+}
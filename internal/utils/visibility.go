@@ -0,0 +1,15 @@
+package utils
+
+import "sync/atomic"
+
+var appHidden atomic.Bool
+
+// SetAppHidden records whether the app is currently hidden (e.g. a background browser tab)
+func SetAppHidden(hidden bool) {
+	appHidden.Store(hidden)
+}
+
+// IsAppHidden reports whether the app is hidden, so timers can pause
+func IsAppHidden() bool {
+	return appHidden.Load()
+}
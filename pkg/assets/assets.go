@@ -9,3 +9,8 @@ import (
 func LoadResourceFromPath(path string) (fyne.Resource, error) {
 	return assetsembed.LoadResourceFromPath(path)
 }
+
+// LoadFlagResource loads the twemoji flag for an ISO 3166-1 alpha-2 country code
+func LoadFlagResource(cca2 string) (fyne.Resource, error) {
+	return LoadResourceFromPath("assets/twemoji_flags_cca2/" + cca2 + ".svg")
+}